@@ -0,0 +1,7 @@
+package certificate
+
+// Certificate is a data structure to hold guest cluster certificate related
+// configuration.
+type Certificate struct {
+	Backend string
+}
@@ -0,0 +1,9 @@
+package cilium
+
+// Cilium is a data structure to hold guest cluster Cilium specific
+// configuration flags.
+type Cilium struct {
+	MeshIDMax       string
+	MeshIDMin       string
+	MeshIDNamespace string
+}
@@ -2,19 +2,25 @@ package cluster
 
 import (
 	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/calico"
+	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/certificate"
+	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/cilium"
 	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/docker"
 	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/etcd"
 	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/kubernetes"
 	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/provider"
+	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/transition"
 	"github.com/giantswarm/cluster-operator/v5/flag/guest/cluster/vault"
 )
 
 // Cluster is a data structure to hold cluster specific configuration flags.
 type Cluster struct {
-	Calico     calico.Calico
-	Docker     docker.Docker
-	Etcd       etcd.Etcd
-	Kubernetes kubernetes.Kubernetes
-	Provider   provider.Provider
-	Vault      vault.Vault
+	Calico      calico.Calico
+	Certificate certificate.Certificate
+	Cilium      cilium.Cilium
+	Docker      docker.Docker
+	Etcd        etcd.Etcd
+	Kubernetes  kubernetes.Kubernetes
+	Provider    provider.Provider
+	Transition  transition.Transition
+	Vault       vault.Vault
 }
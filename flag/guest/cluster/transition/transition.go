@@ -0,0 +1,8 @@
+package transition
+
+// Transition is a data structure to hold guest cluster configuration flags
+// of creation and upgrade transitions.
+type Transition struct {
+	CreationStuckThreshold string
+	UpgradeStuckThreshold  string
+}
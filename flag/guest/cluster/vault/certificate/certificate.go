@@ -3,5 +3,6 @@ package certificate
 // Certificate is a data structure to hold guest cluster vault certificates
 // related configuration.
 type Certificate struct {
-	TTL string
+	CATTL string
+	TTL   string
 }
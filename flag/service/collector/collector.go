@@ -0,0 +1,7 @@
+package collector
+
+// Collector is a data structure to hold the configuration of the metrics
+// collectors.
+type Collector struct {
+	CertificateExpiryThreshold string
+}
@@ -3,5 +3,6 @@ package registry
 // Registry is a data structure to hold docker registry specific configuration
 // flags.
 type Registry struct {
-	Domain string
+	Domain  string
+	Mirrors string
 }
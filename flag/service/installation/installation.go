@@ -1,5 +1,7 @@
 package installation
 
 type Installation struct {
-	Name string
+	APIEndpoint string
+	Name        string
+	Region      string
 }
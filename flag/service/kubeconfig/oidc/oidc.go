@@ -0,0 +1,8 @@
+package oidc
+
+// OIDC is a data structure to hold OIDC kubeconfig specific configuration
+// flags.
+type OIDC struct {
+	ClientID  string
+	IssuerURL string
+}
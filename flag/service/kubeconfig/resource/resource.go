@@ -2,5 +2,6 @@ package resource
 
 // Secret is a data structure to hold Secret specific configuration flags.
 type Secret struct {
-	Namespace string
+	Namespace         string
+	NamespaceSelector string
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/giantswarm/operatorkit/v8/pkg/flag/service/kubernetes"
 
+	"github.com/giantswarm/cluster-operator/v5/flag/service/collector"
 	"github.com/giantswarm/cluster-operator/v5/flag/service/image"
 	"github.com/giantswarm/cluster-operator/v5/flag/service/installation"
 	"github.com/giantswarm/cluster-operator/v5/flag/service/kubeconfig"
@@ -12,6 +13,7 @@ import (
 
 // Service is an intermediate data structure for command line configuration flags.
 type Service struct {
+	Collector    collector.Collector
 	Image        image.Image
 	Installation installation.Installation
 	KubeConfig   kubeconfig.KubeConfig
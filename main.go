@@ -110,20 +110,36 @@ func mainE() error {
 
 	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Calico.CIDR, "", "Prefix length for the CIDR block used by Calico.")
 	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Calico.Subnet, "", "Network address for the CIDR block used by Calico.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Certificate.Backend, "certconfig", "Backend issuing tenant cluster certificates. One of certconfig, cert-manager.")
+	daemonCommand.PersistentFlags().Int(f.Guest.Cluster.Cilium.MeshIDMax, 255, "Upper bound of the Cilium cluster mesh IDs allocated to clusters.")
+	daemonCommand.PersistentFlags().Int(f.Guest.Cluster.Cilium.MeshIDMin, 1, "Lower bound of the Cilium cluster mesh IDs allocated to clusters.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Cilium.MeshIDNamespace, "giantswarm", "Namespace of the ConfigMap recording the allocated Cilium cluster mesh IDs, usually the namespace of the operator.")
 	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Kubernetes.API.ClusterIPRange, "", "CIDR Range for Pods in cluster.")
 	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Kubernetes.ClusterDomain, "cluster.local", "Internal Kubernetes domain.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Transition.CreationStuckThreshold, "30m", "Duration after which a cluster still creating is reported in the CreationStuck condition of the Cluster CR.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Transition.UpgradeStuckThreshold, "2h", "Duration after which a cluster still upgrading is reported in the UpgradeStuck condition of the Cluster CR.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Vault.Certificate.CATTL, "87600h", "Vault CA TTL. Certificate TTLs configured per cluster must be lower.")
 	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Vault.Certificate.TTL, "", "Vault certificate TTL.")
 
+	daemonCommand.PersistentFlags().String(f.Service.Collector.CertificateExpiryThreshold, "720h", "Remaining certificate lifetime below which warning events are emitted on the Cluster CR.")
+
 	daemonCommand.PersistentFlags().String(f.Service.Image.Registry.Domain, "quay.io", "Image registry.")
+	daemonCommand.PersistentFlags().StringSlice(f.Service.Image.Registry.Mirrors, []string{}, "Image registry mirrors.")
 
-	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.Secret.Namespace, "giantswarm", "The namespace where kubeconfig secrets are located.")
+	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.OIDC.ClientID, "", "OIDC client ID used in OIDC kubeconfigs for human users.")
+	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.OIDC.IssuerURL, "", "OIDC issuer URL used in OIDC kubeconfigs for human users. OIDC kubeconfigs are not generated when empty.")
+	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.Secret.Namespace, "", "The namespace where kubeconfig secrets are mirrored to. Mirroring is disabled when empty.")
+	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.Secret.NamespaceSelector, "", "Label selector of additional namespaces kubeconfig secrets are mirrored to.")
+	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.Variants, "", "YAML list of kubeconfig variants generated per tenant cluster. When empty a single admin kubeconfig is generated.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.Address, "", "Address used to connect to Kubernetes. When empty in-cluster config is created.")
 	daemonCommand.PersistentFlags().Bool(f.Service.Kubernetes.InCluster, true, "Whether to use the in-cluster config to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.CAFile, "", "Certificate authority file path to use to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.CrtFile, "", "Certificate file path to use to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.KeyFile, "", "Key file path to use to authenticate with Kubernetes.")
 
+	daemonCommand.PersistentFlags().String(f.Service.Installation.APIEndpoint, "", "API endpoint of the management cluster of the installation.")
 	daemonCommand.PersistentFlags().String(f.Service.Installation.Name, "", "Name of the installation.")
+	daemonCommand.PersistentFlags().String(f.Service.Installation.Region, "", "Region of the management cluster of the installation.")
 	daemonCommand.PersistentFlags().String(f.Service.Provider.Kind, "", "Provider of the installation. One of aws, azure, kvm.")
 
 	daemonCommand.PersistentFlags().String(f.Service.Release.App.Config.Default, "", "Default properties for app.")
@@ -3,9 +3,73 @@
 package annotation
 
 const (
+	// APICertAltNames is the name of the annotation on the Cluster CR holding
+	// a comma separated list of additional DNS names of the API certificate of
+	// the tenant cluster.
+	APICertAltNames = "cluster-operator.giantswarm.io/api-cert-alt-names"
+
+	// APICertIPSANs is the name of the annotation on the Cluster CR holding a
+	// comma separated list of additional IP addresses of the API certificate
+	// of the tenant cluster.
+	APICertIPSANs = "cluster-operator.giantswarm.io/api-cert-ip-sans"
+
+	// CertBackend is the name of the annotation on the Cluster CR holding the
+	// certificate backend of the tenant cluster, either certconfig or
+	// cert-manager. It is set once when the cluster is created, so that
+	// changes of the installation wide default do not move existing clusters
+	// to another backend.
+	CertBackend = "cluster-operator.giantswarm.io/cert-backend"
+
+	// CertTTL is the name of the annotation on the Cluster CR overriding the
+	// installation wide TTL of all certificates of the tenant cluster.
+	CertTTL = "cluster-operator.giantswarm.io/cert-ttl"
+
+	// CertTTLPrefix is the prefix of annotations on the Cluster CR overriding
+	// the TTL of the certificate of a single cluster component. The rest of
+	// the annotation name is the component, e.g.
+	// cert-ttl.cluster-operator.giantswarm.io/api.
+	CertTTLPrefix = "cert-ttl.cluster-operator.giantswarm.io/"
+
+	// CertificatesRotated is the name of the annotation on the Cluster CR
+	// holding the certificate rotation generation which was last rotated, or
+	// is being rotated, by the operator.
+	CertificatesRotated = "cluster-operator.giantswarm.io/certificates-rotated"
+
 	// ChartOperator is used to filter annotations.
 	ChartOperator = "chart-operator.giantswarm.io"
 
+	// CiliumClusterMeshID is the name of the annotation on the Cluster CR
+	// holding the Cilium cluster mesh ID allocated to the tenant cluster.
+	CiliumClusterMeshID = "cilium.giantswarm.io/cluster-mesh-id"
+
+	// CiliumClusterMeshEnabled is the name of the annotation on the Cluster CR
+	// enabling Cilium cluster mesh for the tenant cluster, which requires a
+	// mesh ID to be allocated.
+	CiliumClusterMeshEnabled = "cilium.giantswarm.io/cluster-mesh-enabled"
+
+	// CiliumENIPrefixDelegation is the name of the annotation on the Cluster CR
+	// enabling prefix delegation for Cilium in ENI mode.
+	CiliumENIPrefixDelegation = "cilium.giantswarm.io/eni-prefix-delegation"
+
+	// CiliumENIPreAllocate is the name of the annotation on the Cluster CR
+	// configuring the number of IPs Cilium pre-allocates per node in ENI mode.
+	CiliumENIPreAllocate = "cilium.giantswarm.io/eni-pre-allocate"
+
+	// CiliumENIReleaseExcessIPs is the name of the annotation on the Cluster CR
+	// controlling whether Cilium releases excess IPs of ENIs in ENI mode. It
+	// defaults to true, and to false with prefix delegation.
+	CiliumENIReleaseExcessIPs = "cilium.giantswarm.io/eni-release-excess-ips"
+
+	// CiliumENISecurityGroupTags is the name of the annotation on the Cluster CR
+	// holding comma separated key=value tags used to select the security
+	// groups of ENIs created by Cilium in ENI mode.
+	CiliumENISecurityGroupTags = "cilium.giantswarm.io/eni-security-group-tags"
+
+	// CiliumENISubnetTags is the name of the annotation on the Cluster CR
+	// holding comma separated key=value tags used to select the subnets of
+	// ENIs created by Cilium in ENI mode.
+	CiliumENISubnetTags = "cilium.giantswarm.io/eni-subnet-tags"
+
 	// CordonReason is the name of the annotation that indicates
 	// the reason of why chart-operator should not apply any update on this chart CR.
 	CordonReason = "chart-operator.giantswarm.io/cordon-reason"
@@ -18,10 +82,112 @@ const (
 	// the custom resource should be deleted without deleting the Helm release.
 	DeleteCustomResourceOnly = "chart-operator.giantswarm.io/delete-custom-resource-only"
 
+	// ExternalDNSDomainFilters is the name of the annotation on the Cluster CR
+	// holding a comma separated list of additional domains external-dns in the
+	// tenant cluster manages, e.g. the domains of private hosted zones.
+	ExternalDNSDomainFilters = "cluster-operator.giantswarm.io/external-dns-domain-filters"
+
+	// ExternalDNSZoneType is the name of the annotation on the Cluster CR
+	// restricting external-dns in AWS tenant clusters to either public or
+	// private hosted zones.
+	ExternalDNSZoneType = "cluster-operator.giantswarm.io/external-dns-zone-type"
+
 	// ForceHelmUpgrade is the name of the annotation that controls whether force
 	// is used when upgrading the Helm release.
 	ForceHelmUpgrade = "chart-operator.giantswarm.io/force-helm-upgrade"
 
+	// HTTPProxy is the name of the annotation on the Cluster CR configuring the
+	// HTTP proxy used by apps in the tenant cluster.
+	HTTPProxy = "cluster-operator.giantswarm.io/http-proxy"
+
+	// HTTPSProxy is the name of the annotation on the Cluster CR configuring the
+	// HTTPS proxy used by apps in the tenant cluster.
+	HTTPSProxy = "cluster-operator.giantswarm.io/https-proxy"
+
+	// KubeConfigOwner is the name of the annotation on mirrored kubeconfig
+	// Secrets holding the namespace and name of the Cluster CR owning them,
+	// e.g. org-example/ab12c.
+	KubeConfigOwner = "cluster-operator.giantswarm.io/kubeconfig-owner"
+
+	// KubeConfigSource is the name of the annotation on mirrored kubeconfig
+	// Secrets holding the namespace and name of the kubeconfig Secret they
+	// mirror.
+	KubeConfigSource = "cluster-operator.giantswarm.io/kubeconfig-source"
+
+	// NodePoolDesiredVersion is the name of the annotation on MachineDeployment
+	// CRs holding the provider operator version the nodes of the node pool are
+	// upgraded to.
+	NodePoolDesiredVersion = "cluster-operator.giantswarm.io/desired-node-version"
+
+	// NodePoolOutdatedNodes is the name of the annotation on MachineDeployment
+	// CRs holding the number of nodes of the node pool not on the desired
+	// version yet.
+	NodePoolOutdatedNodes = "cluster-operator.giantswarm.io/outdated-nodes"
+
+	// NodePoolUpgradedNodes is the name of the annotation on MachineDeployment
+	// CRs holding the number of nodes of the node pool on the desired version.
+	NodePoolUpgradedNodes = "cluster-operator.giantswarm.io/upgraded-nodes"
+
+	// NoProxy is the name of the annotation on the Cluster CR holding a comma
+	// separated list of additional destinations which must not be proxied.
+	NoProxy = "cluster-operator.giantswarm.io/no-proxy"
+
+	// ObservedCreateTransition is the name of the annotation on the Cluster CR
+	// holding the start time of the creation transition which was observed in
+	// the transition duration histogram, so that it is not observed again
+	// after restarts.
+	ObservedCreateTransition = "cluster-operator.giantswarm.io/observed-create-transition"
+
+	// ObservedUpdateTransition is the name of the annotation on the Cluster CR
+	// holding the start time of the latest update transition which was
+	// observed in the transition duration histogram.
+	ObservedUpdateTransition = "cluster-operator.giantswarm.io/observed-update-transition"
+
+	// RotateCertificates is the name of the annotation on the Cluster CR
+	// requesting the rotation of all certificates of the tenant cluster.
+	// Setting it to a new value, e.g. an increasing number, triggers another
+	// rotation.
+	RotateCertificates = "cluster-operator.giantswarm.io/rotate-certificates"
+
+	// RotationGeneration is the name of the annotation on CertConfig CRs
+	// holding the certificate rotation generation the CertConfig CR was last
+	// rotated for.
+	RotationGeneration = "cluster-operator.giantswarm.io/rotation-generation"
+
+	// RegistryDomain is the name of the annotation on the Cluster CR overriding
+	// the installation wide image registry used by apps in the tenant cluster.
+	RegistryDomain = "cluster-operator.giantswarm.io/registry-domain"
+
+	// RegistryMirrors is the name of the annotation on the Cluster CR holding a
+	// comma separated list of image registry mirrors overriding the
+	// installation wide mirrors.
+	RegistryMirrors = "cluster-operator.giantswarm.io/registry-mirrors"
+
+	// IngressAllowlist is the name of the annotation on the Cluster CR holding
+	// a comma separated list of CIDRs allowed to access the ingress controller
+	// load balancer of the tenant cluster.
+	IngressAllowlist = "cluster-operator.giantswarm.io/ingress-allowlist"
+
+	// IngressLoadBalancerScheme is the name of the annotation on the Cluster CR
+	// selecting whether the ingress controller load balancer of the tenant
+	// cluster is internal or internet-facing.
+	IngressLoadBalancerScheme = "cluster-operator.giantswarm.io/ingress-load-balancer-scheme"
+
+	// IngressLoadBalancerType is the name of the annotation on the Cluster CR
+	// selecting the AWS load balancer type, elb or nlb, of the ingress
+	// controller of the tenant cluster.
+	IngressLoadBalancerType = "cluster-operator.giantswarm.io/ingress-load-balancer-type"
+
+	// IngressProxyProtocol is the name of the annotation on the Cluster CR
+	// controlling whether the ingress controller of the tenant cluster uses
+	// the proxy protocol.
+	IngressProxyProtocol = "cluster-operator.giantswarm.io/ingress-proxy-protocol"
+
+	// ValuesPrefix is the prefix of annotations on the Cluster CR overriding
+	// cluster values. The rest of the annotation name is the dotted path of
+	// the overridden value, e.g. values.cluster-operator.giantswarm.io/foo.bar.
+	ValuesPrefix = "values.cluster-operator.giantswarm.io/"
+
 	// Notes is for informational messages for resources generated by the operator.
 	Notes = "giantswarm.io/notes"
 
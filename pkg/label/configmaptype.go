@@ -9,6 +9,9 @@ const (
 	// ConfigMapTypeApp is a label value for app configmaps managed by the
 	// operator.
 	ConfigMapTypeApp = "app"
+	// ConfigMapTypeKubeConfig is a label value for kubeconfig configmaps
+	// managed by the operator.
+	ConfigMapTypeKubeConfig = "kubeconfig"
 	// ConfigMapTypeUser is a label value for user configmaps created by the
 	// operator and edited by users to override chart values.
 	ConfigMapTypeUser = "user"
@@ -0,0 +1,10 @@
+package label
+
+const (
+	// KubeConfigMirror marks copies of kubeconfig Secrets maintained in the
+	// configured kubeconfig namespaces.
+	KubeConfigMirror = "cluster-operator.giantswarm.io/kubeconfig-mirror"
+	// KubeConfigVariant is the name of the kubeconfig variant a kubeconfig
+	// Secret of a tenant cluster was generated for.
+	KubeConfigVariant = "cluster-operator.giantswarm.io/kubeconfig-variant"
+)
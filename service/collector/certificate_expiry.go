@@ -0,0 +1,200 @@
+package collector
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+)
+
+const (
+	// certificateExpiringReason is the reason of the warning event emitted on
+	// the Cluster CR when one of its certificates expires soon.
+	certificateExpiringReason = "CertificateExpiring"
+)
+
+var (
+	certificateExpiry *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCertificate, "expiry_seconds"),
+		"Seconds until the workload cluster certificate expires, negative when already expired.",
+		[]string{
+			"cluster_id",
+			"component",
+		},
+		nil,
+	)
+)
+
+type CertificateExpiryConfig struct {
+	Event  recorder.Interface
+	Logger micrologger.Logger
+	Reader client.Reader
+
+	Threshold time.Duration
+}
+
+// CertificateExpiry implements the collector interface, exposing the remaining
+// lifetime of the certificates issued for workload clusters.
+type CertificateExpiry struct {
+	event  recorder.Interface
+	logger micrologger.Logger
+	reader client.Reader
+
+	threshold time.Duration
+
+	mutex sync.Mutex
+	// warned holds the expiry of every certificate a warning event was
+	// emitted for, so that the event is emitted once per certificate and not
+	// on every scrape. Reissued certificates have a new expiry.
+	warned map[string]time.Time
+}
+
+func NewCertificateExpiry(config CertificateExpiryConfig) (*CertificateExpiry, error) {
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
+
+	if config.Threshold <= 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Threshold must be positive", config)
+	}
+
+	c := &CertificateExpiry{
+		event:  config.Event,
+		logger: config.Logger,
+		reader: config.Reader,
+
+		threshold: config.Threshold,
+
+		warned: map[string]time.Time{},
+	}
+
+	return c, nil
+}
+
+func (c *CertificateExpiry) Collect(ch chan<- prometheus.Metric) error {
+	ctx := context.Background()
+
+	clusters := map[string]*apiv1beta1.Cluster{}
+	{
+		var list apiv1beta1.ClusterList
+		err := c.reader.List(
+			ctx,
+			&list,
+			client.MatchingLabels{label.OperatorVersion: project.Version()},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for i := range list.Items {
+			clusters[key.ClusterID(&list.Items[i])] = &list.Items[i]
+		}
+	}
+
+	// The certificate Secrets are written by cert-operator based on the
+	// CertConfig CRs we manage. They carry the same certificate and cluster
+	// labels, which is how the certs searcher finds them as well. The cache
+	// only holds Secrets with these labels.
+	var secrets corev1.SecretList
+	{
+		err := c.reader.List(
+			ctx,
+			&secrets,
+			client.HasLabels{label.Certificate, label.Cluster},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	warned := map[string]time.Time{}
+
+	for _, s := range secrets.Items {
+		cl, ok := clusters[s.Labels[label.Cluster]]
+		if !ok {
+			continue
+		}
+
+		component := s.Labels[label.Certificate]
+
+		notAfter, err := certificateNotAfter(s)
+		if err != nil {
+			c.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("could not parse certificate %#q of cluster %#q", component, key.ClusterID(cl)), "stack", microerror.JSON(err))
+			continue
+		}
+
+		remaining := notAfter.Sub(now)
+
+		ch <- prometheus.MustNewConstMetric(
+			certificateExpiry,
+			prometheus.GaugeValue,
+			remaining.Seconds(),
+			key.ClusterID(cl),
+			component,
+		)
+
+		if remaining < c.threshold {
+			k := fmt.Sprintf("%s/%s", key.ClusterID(cl), component)
+			if !c.warned[k].Equal(notAfter) {
+				c.event.Warn(ctx, cl, certificateExpiringReason, fmt.Sprintf("certificate %#q expires at %s", component, notAfter.UTC().Format(time.RFC3339)))
+			}
+			warned[k] = notAfter
+		}
+	}
+
+	// Certificates which were reissued or removed are forgotten.
+	c.warned = warned
+
+	return nil
+}
+
+func (c *CertificateExpiry) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- certificateExpiry
+	return nil
+}
+
+// certificateNotAfter returns the expiry time of the PEM encoded certificate
+// stored in the crt key of the given certificate Secret.
+func certificateNotAfter(secret corev1.Secret) (time.Time, error) {
+	crt, ok := secret.Data["crt"]
+	if !ok {
+		return time.Time{}, microerror.Maskf(invalidCertificateError, "%#q key missing", "crt")
+	}
+
+	block, _ := pem.Decode(crt)
+	if block == nil {
+		return time.Time{}, microerror.Maskf(invalidCertificateError, "%#q key does not contain PEM data", "crt")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, microerror.Maskf(invalidCertificateError, "%#q key does not contain a certificate: %s", "crt", err)
+	}
+
+	return cert.NotAfter, nil
+}
@@ -0,0 +1,220 @@
+package collector
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+type countingRecorder struct {
+	warnings int
+}
+
+func (r *countingRecorder) Emit(ctx context.Context, obj pkgruntime.Object, reason, message string) {}
+
+func (r *countingRecorder) Warn(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	r.warnings++
+}
+
+func TestCollectCertificateExpiry(t *testing.T) {
+	ctx := context.Background()
+	reader := unittest.FakeK8sClient().CtrlClient()
+
+	cluster := &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck",
+			Namespace: "org-giantswarm",
+			Labels: map[string]string{
+				label.Cluster:         "8y5ck",
+				label.OperatorVersion: project.Version(),
+			},
+		},
+	}
+	api := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck-api",
+			Namespace: "org-giantswarm",
+			Labels: map[string]string{
+				label.Certificate: "api",
+				label.Cluster:     "8y5ck",
+			},
+		},
+		Data: map[string][]byte{
+			"crt": newCertificatePEM(t, time.Now().Add(time.Hour)),
+		},
+	}
+	etcd := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck-etcd",
+			Namespace: "org-giantswarm",
+			Labels: map[string]string{
+				label.Certificate: "etcd",
+				label.Cluster:     "8y5ck",
+			},
+		},
+		Data: map[string][]byte{
+			"crt": newCertificatePEM(t, time.Now().Add(30*24*time.Hour)),
+		},
+	}
+	for _, o := range []client.Object{cluster, api, etcd} {
+		err := reader.Create(ctx, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	event := &countingRecorder{}
+
+	c, err := NewCertificateExpiry(CertificateExpiryConfig{
+		Event:  event,
+		Logger: microloggertest.New(),
+		Reader: reader,
+
+		Threshold: 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collect := func() int {
+		t.Helper()
+
+		ch := make(chan prometheus.Metric, 10)
+		err := c.Collect(ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		close(ch)
+
+		var n int
+		for range ch {
+			n++
+		}
+
+		return n
+	}
+
+	if n := collect(); n != 2 {
+		t.Fatalf("metrics == %d, want %d", n, 2)
+	}
+	if event.warnings != 1 {
+		t.Fatalf("warnings == %d, want %d", event.warnings, 1)
+	}
+
+	// Scraping again must not warn about the same certificate again.
+	collect()
+	collect()
+	if event.warnings != 1 {
+		t.Fatalf("warnings == %d, want %d", event.warnings, 1)
+	}
+
+	// A reissued certificate expiring soon is warned about again.
+	api.Data["crt"] = newCertificatePEM(t, time.Now().Add(2*time.Hour))
+	err = reader.Update(ctx, api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collect()
+	if event.warnings != 2 {
+		t.Fatalf("warnings == %d, want %d", event.warnings, 2)
+	}
+}
+
+func Test_certificateNotAfter(t *testing.T) {
+	notAfter := time.Now().Add(72 * time.Hour).Truncate(time.Second).UTC()
+
+	testCases := []struct {
+		name         string
+		data         map[string][]byte
+		expected     time.Time
+		errorMatcher func(error) bool
+	}{
+		{
+			name: "case 0: valid certificate",
+			data: map[string][]byte{
+				"crt": newCertificatePEM(t, notAfter),
+			},
+			expected: notAfter,
+		},
+		{
+			name:         "case 1: crt key missing",
+			data:         map[string][]byte{},
+			errorMatcher: IsInvalidCertificate,
+		},
+		{
+			name: "case 2: no PEM data",
+			data: map[string][]byte{
+				"crt": []byte("foo"),
+			},
+			errorMatcher: IsInvalidCertificate,
+		},
+		{
+			name: "case 3: no certificate",
+			data: map[string][]byte{
+				"crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("foo")}),
+			},
+			errorMatcher: IsInvalidCertificate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := certificateNotAfter(corev1.Secret{Data: tc.data})
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !actual.Equal(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func newCertificatePEM(t *testing.T, notAfter time.Time) []byte {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "api.8y5ck.k8s.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &k.PublicKey, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -6,7 +6,6 @@ import (
 
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	apiextensionsconditions "github.com/giantswarm/apiextensions/v6/pkg/conditions"
-	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -34,28 +33,28 @@ var (
 )
 
 type ClusterConfig struct {
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	Logger micrologger.Logger
+	Reader client.Reader
 
 	NewCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	Provider                   string
 }
 
 type Cluster struct {
-	k8sClient k8sclient.Interface
-	logger    micrologger.Logger
+	logger micrologger.Logger
+	reader client.Reader
 
 	newCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	provider                   string
 }
 
 func NewCluster(config ClusterConfig) (*Cluster, error) {
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
-	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
 
 	if config.NewCommonClusterObjectFunc == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
@@ -65,8 +64,8 @@ func NewCluster(config ClusterConfig) (*Cluster, error) {
 	}
 
 	c := &Cluster{
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
+		logger: config.Logger,
+		reader: config.Reader,
 
 		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 		provider:                   config.Provider,
@@ -80,7 +79,7 @@ func (c *Cluster) Collect(ch chan<- prometheus.Metric) error {
 
 	var list apiv1beta1.ClusterList
 	{
-		err := c.k8sClient.CtrlClient().List(
+		err := c.reader.List(
 			ctx,
 			&list,
 			client.MatchingLabels{label.OperatorVersion: project.Version()},
@@ -96,7 +95,7 @@ func (c *Cluster) Collect(ch chan<- prometheus.Metric) error {
 		case label.ProviderAWS:
 			cr := c.newCommonClusterObjectFunc()
 			{
-				err := c.k8sClient.CtrlClient().Get(
+				err := c.reader.Get(
 					ctx,
 					key.ObjRefToNamespacedName(key.ObjRefFromCluster(cl)),
 					cr,
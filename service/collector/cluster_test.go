@@ -0,0 +1,114 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func TestCollectCluster(t *testing.T) {
+	ctx := context.Background()
+	reader := unittest.FakeK8sClient().CtrlClient()
+
+	var objects []client.Object
+	for _, id := range []string{"8y5ck", "al9qy"} {
+		operatorVersion := project.Version()
+		if id == "al9qy" {
+			// Clusters reconciled by other operator versions are ignored.
+			operatorVersion = "0.0.1"
+		}
+
+		objects = append(objects,
+			&apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      id,
+					Namespace: "org-giantswarm",
+					Labels: map[string]string{
+						label.Cluster:         id,
+						label.OperatorVersion: operatorVersion,
+					},
+				},
+				Spec: apiv1beta1.ClusterSpec{
+					InfrastructureRef: &corev1.ObjectReference{
+						Kind:      "AWSCluster",
+						Name:      id,
+						Namespace: "org-giantswarm",
+					},
+				},
+			},
+			&infrastructurev1alpha3.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      id,
+					Namespace: "org-giantswarm",
+				},
+				Status: infrastructurev1alpha3.AWSClusterStatus{
+					Cluster: infrastructurev1alpha3.CommonClusterStatus{
+						Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+							unittest.GetUpdatingCondition(30),
+							unittest.GetCreatedCondition(60),
+							unittest.GetCreatingCondition(90),
+						},
+					},
+				},
+			},
+		)
+	}
+	for _, o := range objects {
+		err := reader.Create(ctx, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := NewCluster(ClusterConfig{
+		Logger: microloggertest.New(),
+		Reader: reader,
+
+		NewCommonClusterObjectFunc: func() infrastructurev1alpha3.CommonClusterObject {
+			return &infrastructurev1alpha3.AWSCluster{}
+		},
+		Provider: label.ProviderAWS,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := map[string]float64{}
+	for _, m := range collectMetrics(t, c.Collect) {
+		labels := map[string]string{}
+		for _, l := range m.metric.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["cluster_id"] != "8y5ck" {
+			t.Fatalf("cluster_id == %#q, want %#q", labels["cluster_id"], "8y5ck")
+		}
+
+		status[labels["status"]] = m.metric.GetGauge().GetValue()
+	}
+
+	expected := map[string]float64{
+		infrastructurev1alpha3.ClusterStatusConditionCreating: 0,
+		infrastructurev1alpha3.ClusterStatusConditionCreated:  0,
+		infrastructurev1alpha3.ClusterStatusConditionUpdating: 1,
+		infrastructurev1alpha3.ClusterStatusConditionUpdated:  0,
+		infrastructurev1alpha3.ClusterStatusConditionDeleting: 0,
+	}
+	if len(status) != len(expected) {
+		t.Fatalf("status == %#v, want %#v", status, expected)
+	}
+	for k, v := range expected {
+		if status[k] != v {
+			t.Fatalf("status == %#v, want %#v", status, expected)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
-	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,6 +16,7 @@ import (
 	"github.com/giantswarm/cluster-operator/v5/pkg/label"
 	"github.com/giantswarm/cluster-operator/v5/pkg/project"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
 )
 
 var (
@@ -38,21 +38,37 @@ var (
 		},
 		nil,
 	)
+	clusterTransitionDeleteDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "delete_transition"),
+		"Duration of the ongoing cluster deletion transition.",
+		[]string{
+			"cluster_id",
+			"release_version",
+		},
+		nil,
+	)
 )
 
 type ClusterTransitionConfig struct {
-	K8sClient k8sclient.Interface
+	Durations *prometheus.HistogramVec
 	Logger    micrologger.Logger
+	Reader    client.Reader
 
 	NewCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	Provider                   string
 }
 
 // ClusterTransition implements the ClusterTransition interface, exposing
-// cluster transition information.
+// cluster transition information. Collect only reads from the cache. The
+// durations of completed creations and updates are observed by the
+// clustertransition resource of the cluster controller, which persists its
+// observations on the Cluster CR, and the durations of completed deletions by
+// the clusterdeleting resource. Transitions are only known for AWS, where
+// they are reported in the status conditions of the infrastructure CR.
 type ClusterTransition struct {
-	k8sClient k8sclient.Interface
+	durations *prometheus.HistogramVec
 	logger    micrologger.Logger
+	reader    client.Reader
 
 	newCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	provider                   string
@@ -60,12 +76,15 @@ type ClusterTransition struct {
 
 // NewClusterTransition initiates cluster transition metrics
 func NewClusterTransition(config ClusterTransitionConfig) (*ClusterTransition, error) {
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	if config.Durations == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Durations must not be empty", config)
 	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
 
 	if config.NewCommonClusterObjectFunc == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
@@ -75,8 +94,9 @@ func NewClusterTransition(config ClusterTransitionConfig) (*ClusterTransition, e
 	}
 
 	ct := &ClusterTransition{
-		k8sClient: config.K8sClient,
+		durations: config.Durations,
 		logger:    config.Logger,
+		reader:    config.Reader,
 
 		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 		provider:                   config.Provider,
@@ -90,7 +110,7 @@ func (ct *ClusterTransition) Collect(ch chan<- prometheus.Metric) error {
 
 	var list apiv1beta1.ClusterList
 	{
-		err := ct.k8sClient.CtrlClient().List(
+		err := ct.reader.List(
 			ctx,
 			&list,
 			client.MatchingLabels{label.OperatorVersion: project.Version()},
@@ -102,11 +122,12 @@ func (ct *ClusterTransition) Collect(ch chan<- prometheus.Metric) error {
 
 	for _, cl := range list.Items {
 		cl := cl // dereferencing pointer value into new scope
+		var open []transition.Transition
 		switch ct.provider {
 		case label.ProviderAWS:
 			cr := ct.newCommonClusterObjectFunc()
 			{
-				err := ct.k8sClient.CtrlClient().Get(
+				err := ct.reader.Get(
 					ctx,
 					key.ObjRefToNamespacedName(key.ObjRefFromCluster(cl)),
 					cr,
@@ -139,9 +160,25 @@ func (ct *ClusterTransition) Collect(ch chan<- prometheus.Metric) error {
 						key.ReleaseVersion(cr),
 					)
 				}
+				deleting, deleteTime := getDeleteMetrics(cr.GetCommonClusterStatus())
+				if deleting {
+					ch <- prometheus.MustNewConstMetric(
+						clusterTransitionDeleteDesc,
+						prometheus.GaugeValue,
+						deleteTime,
+						key.ClusterID(cr),
+						key.ReleaseVersion(cr),
+					)
+				}
 			}
+			open = transition.Open(cr.GetCommonClusterStatus())
 		}
+
+		ct.collectOpenTransitions(ch, cl, open)
 	}
+
+	ct.durations.Collect(ch)
+
 	return nil
 }
 
@@ -187,9 +224,30 @@ func getUpdateMetrics(status infrastructurev1alpha3.CommonClusterStatus) (bool,
 	return false, 0
 }
 
+// getDeleteMetrics returns the duration of the deletion of the cluster. The
+// Deleted condition is usually never observed since the CRs are gone once the
+// deletion finished, so the duration of the ongoing deletion is reported.
+func getDeleteMetrics(status infrastructurev1alpha3.CommonClusterStatus) (bool, float64) {
+	if !status.HasDeletingCondition() {
+		return false, 0
+	}
+
+	t1 := status.GetDeletingCondition().LastTransitionTime.Time
+
+	if status.HasDeletedCondition() {
+		t2 := status.GetDeletedCondition().LastTransitionTime.Time
+		return true, t2.Sub(t1).Seconds()
+	}
+
+	return true, time.Since(t1).Seconds()
+}
+
 func (ct *ClusterTransition) Describe(ch chan<- *prometheus.Desc) error {
 	ch <- clusterTransitionCreateDesc
 	ch <- clusterTransitionUpdateDesc
+	ch <- clusterTransitionDeleteDesc
+	ch <- clusterTransitionOpenDesc
+	ct.durations.Describe(ch)
 
 	return nil
 }
@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
+)
+
+var (
+	clusterTransitionOpenDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "open_transition_age_seconds"),
+		"Age of the ongoing cluster transitions.",
+		[]string{
+			"cluster_id",
+			"provider",
+			"release_version",
+			"transition",
+		},
+		nil,
+	)
+)
+
+func (ct *ClusterTransition) collectOpenTransitions(ch chan<- prometheus.Metric, cl apiv1beta1.Cluster, transitions []transition.Transition) {
+	if cl.DeletionTimestamp != nil {
+		transitions = append(transitions, transition.Transition{
+			Name:  transition.Delete,
+			Start: cl.DeletionTimestamp.Time,
+		})
+	}
+
+	for _, t := range transitions {
+		ch <- prometheus.MustNewConstMetric(
+			clusterTransitionOpenDesc,
+			prometheus.GaugeValue,
+			time.Since(t.Start).Seconds(),
+			key.ClusterID(&cl),
+			ct.provider,
+			key.ReleaseVersion(&cl),
+			t.Name,
+		)
+	}
+}
@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func TestCollectClusterTransitionReadOnly(t *testing.T) {
+	ctx := context.Background()
+	reader := unittest.FakeK8sClient().CtrlClient()
+
+	awsCluster := &infrastructurev1alpha3.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck",
+			Namespace: "org-giantswarm",
+		},
+		Status: infrastructurev1alpha3.AWSClusterStatus{
+			Cluster: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetUpdatingCondition(30),
+					unittest.GetCreatedCondition(60),
+					unittest.GetCreatingCondition(90),
+				},
+			},
+		},
+	}
+	cl := &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck",
+			Namespace: "org-giantswarm",
+			Labels: map[string]string{
+				label.Cluster:         "8y5ck",
+				label.OperatorVersion: project.Version(),
+			},
+		},
+		Spec: apiv1beta1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind:      "AWSCluster",
+				Name:      "8y5ck",
+				Namespace: "org-giantswarm",
+			},
+		},
+	}
+	for _, o := range []client.Object{awsCluster, cl} {
+		err := reader.Create(ctx, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	resourceVersion := cl.ResourceVersion
+
+	ct := newTestClusterTransition(t, reader)
+
+	var open int
+	for _, m := range collectMetrics(t, ct.Collect) {
+		if m.desc == clusterTransitionOpenDesc.String() {
+			open++
+		}
+	}
+	if open != 1 {
+		t.Fatalf("open transitions == %d, want %d", open, 1)
+	}
+
+	// Completed transitions are observed by the clustertransition resource,
+	// so collecting neither writes the Cluster CR nor observes them.
+	var current apiv1beta1.Cluster
+	err := reader.Get(ctx, types.NamespacedName{Name: cl.Name, Namespace: cl.Namespace}, &current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.ResourceVersion != resourceVersion {
+		t.Fatalf("resource version == %#q, want %#q", current.ResourceVersion, resourceVersion)
+	}
+	if c := sampleCount(t, ct, transition.Create); c != 0 {
+		t.Fatalf("create observations == %d, want %d", c, 0)
+	}
+}
+
+func newTestClusterTransition(t *testing.T, reader client.Reader) *ClusterTransition {
+	t.Helper()
+
+	c := ClusterTransitionConfig{
+		Durations: transition.NewDurations(),
+		Logger:    microloggertest.New(),
+		Reader:    reader,
+
+		NewCommonClusterObjectFunc: func() infrastructurev1alpha3.CommonClusterObject {
+			return &infrastructurev1alpha3.AWSCluster{}
+		},
+		Provider: label.ProviderAWS,
+	}
+
+	ct, err := NewClusterTransition(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ct
+}
+
+func sampleCount(t *testing.T, ct *ClusterTransition, name string) uint64 {
+	t.Helper()
+
+	var m dto.Metric
+	err := ct.durations.WithLabelValues(label.ProviderAWS, "", name).(prometheus.Metric).Write(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m.GetHistogram().GetSampleCount()
+}
@@ -160,3 +160,68 @@ func TestCollectClusterTransition(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectClusterDeleteTransition(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status infrastructurev1alpha3.CommonClusterStatus
+
+		expectDeleting bool
+		expectDeleted  int
+	}{
+		// the cluster is not deleting
+		{
+			name: "case 0",
+			status: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetCreatedCondition(0),
+					unittest.GetCreatingCondition(30),
+				},
+			},
+
+			expectDeleting: false,
+			expectDeleted:  0,
+		},
+		// the cluster is deleting
+		{
+			name: "case 1",
+			status: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetDeletingCondition(10),
+					unittest.GetCreatedCondition(60),
+					unittest.GetCreatingCondition(90),
+				},
+			},
+
+			expectDeleting: true,
+			expectDeleted:  10 * 60,
+		},
+		// the cluster is deleted
+		{
+			name: "case 2",
+			status: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetDeletedCondition(5),
+					unittest.GetDeletingCondition(20),
+					unittest.GetCreatedCondition(60),
+					unittest.GetCreatingCondition(90),
+				},
+			},
+
+			expectDeleting: true,
+			expectDeleted:  15*60 - 1,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			deleting, deleted := getDeleteMetrics(tc.status)
+			if deleting != tc.expectDeleting {
+				t.Fatalf("expected %v, got %v", tc.expectDeleting, deleting)
+			}
+			if int(deleted) != tc.expectDeleted {
+				t.Fatalf("expected %v, got %v", tc.expectDeleted, int(deleted))
+			}
+		})
+	}
+}
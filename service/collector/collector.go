@@ -1,8 +1,9 @@
 package collector
 
 const (
-	GaugeValue        float64 = 1
-	namespace         string  = "cluster_operator"
-	subsystemCluster  string  = "cluster"
-	subsystemNodePool string  = "node_pool"
+	GaugeValue           float64 = 1
+	namespace            string  = "cluster_operator"
+	subsystemCertificate string  = "certificate"
+	subsystemCluster     string  = "cluster"
+	subsystemNodePool    string  = "node_pool"
 )
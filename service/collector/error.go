@@ -12,3 +12,21 @@ var invalidConfigError = &microerror.Error{
 func IsInvalidConfig(err error) bool {
 	return microerror.Cause(err) == invalidConfigError
 }
+
+var invalidCertificateError = &microerror.Error{
+	Kind: "invalidCertificateError",
+}
+
+// IsInvalidCertificate asserts invalidCertificateError.
+func IsInvalidCertificate(err error) bool {
+	return microerror.Cause(err) == invalidCertificateError
+}
+
+var cacheNotSyncedError = &microerror.Error{
+	Kind: "cacheNotSyncedError",
+}
+
+// IsCacheNotSynced asserts cacheNotSyncedError.
+func IsCacheNotSynced(err error) bool {
+	return microerror.Cause(err) == cacheNotSyncedError
+}
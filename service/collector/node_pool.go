@@ -2,14 +2,15 @@ package collector
 
 import (
 	"context"
+	"strconv"
 
-	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/prometheus/client_golang/prometheus"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
 	"github.com/giantswarm/cluster-operator/v5/pkg/label"
 	"github.com/giantswarm/cluster-operator/v5/pkg/project"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
@@ -35,6 +36,17 @@ var (
 		nil,
 	)
 
+	nodePoolUpgradedNodes *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemNodePool, "upgraded_nodes"),
+		"Number of workers in a node pool on the desired provider operator version as provided by the annotations of the MachineDeployment CR.",
+		[]string{
+			"cluster_id",
+			"node_pool_id",
+			"desired_version",
+		},
+		nil,
+	)
+
 	nodePoolReadyWorkers *prometheus.Desc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, subsystemNodePool, "ready_workers"),
 		"Number of ready workers in all node pools for a specific cluster as provided by the MachineDeployment CRs associated with a given cluster ID.",
@@ -47,26 +59,26 @@ var (
 )
 
 type NodePoolConfig struct {
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	Logger micrologger.Logger
+	Reader client.Reader
 }
 
 type NodePool struct {
-	k8sClient k8sclient.Interface
-	logger    micrologger.Logger
+	logger micrologger.Logger
+	reader client.Reader
 }
 
 func NewNodePool(config NodePoolConfig) (*NodePool, error) {
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
-	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
 
 	np := &NodePool{
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
+		logger: config.Logger,
+		reader: config.Reader,
 	}
 
 	return np, nil
@@ -77,7 +89,7 @@ func (np *NodePool) Collect(ch chan<- prometheus.Metric) error {
 
 	var list apiv1beta1.MachineDeploymentList
 	{
-		err := np.k8sClient.CtrlClient().List(
+		err := np.reader.List(
 			ctx,
 			&list,
 			client.MatchingLabels{label.OperatorVersion: project.Version()},
@@ -91,6 +103,11 @@ func (np *NodePool) Collect(ch chan<- prometheus.Metric) error {
 		id      string
 		desired int
 		ready   int
+
+		// desiredVersion and upgraded are only known once the
+		// machinedeploymentstatus resource annotated the MachineDeployment CR.
+		desiredVersion string
+		upgraded       int
 	}
 
 	nodePoolMap := make(map[string][]nodePool)
@@ -104,6 +121,12 @@ func (np *NodePool) Collect(ch chan<- prometheus.Metric) error {
 			ready:   int(md.Status.ReadyReplicas),
 		}
 
+		upgraded, err := strconv.Atoi(md.Annotations[annotation.NodePoolUpgradedNodes])
+		if err == nil {
+			np.desiredVersion = md.Annotations[annotation.NodePoolDesiredVersion]
+			np.upgraded = upgraded
+		}
+
 		nodePoolMap[key.ClusterID(&md)] = append(nodePoolMap[key.ClusterID(&md)], np)
 	}
 
@@ -133,6 +156,17 @@ func (np *NodePool) Collect(ch chan<- prometheus.Metric) error {
 				cid,
 				np.id,
 			)
+
+			if np.desiredVersion != "" {
+				ch <- prometheus.MustNewConstMetric(
+					nodePoolUpgradedNodes,
+					prometheus.GaugeValue,
+					float64(np.upgraded),
+					cid,
+					np.id,
+					np.desiredVersion,
+				)
+			}
 		}
 	}
 
@@ -143,6 +177,7 @@ func (np *NodePool) Describe(ch chan<- *prometheus.Desc) error {
 	ch <- nodePoolCount
 	ch <- nodePoolDesiredWorkers
 	ch <- nodePoolReadyWorkers
+	ch <- nodePoolUpgradedNodes
 
 	return nil
 }
@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func TestCollectNodePoolUpgradedNodes(t *testing.T) {
+	ctx := context.Background()
+	reader := unittest.FakeK8sClient().CtrlClient()
+
+	upgrading := newTestMachineDeployment("a1b2c", map[string]string{
+		annotation.NodePoolDesiredVersion: "14.1.0",
+		annotation.NodePoolOutdatedNodes:  "1",
+		annotation.NodePoolUpgradedNodes:  "2",
+	})
+	unknown := newTestMachineDeployment("d3e4f", nil)
+	for _, o := range []client.Object{upgrading, unknown} {
+		err := reader.Create(ctx, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := NewNodePool(NodePoolConfig{
+		Logger: microloggertest.New(),
+		Reader: reader,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := collectMetrics(t, c.Collect)
+
+	upgraded := map[string]float64{}
+	for _, m := range metrics {
+		if m.desc != nodePoolUpgradedNodes.String() {
+			continue
+		}
+
+		labels := map[string]string{}
+		for _, l := range m.metric.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["cluster_id"] != "8y5ck" || labels["desired_version"] != "14.1.0" {
+			t.Fatalf("labels == %#v, want cluster_id %#q and desired_version %#q", labels, "8y5ck", "14.1.0")
+		}
+
+		upgraded[labels["node_pool_id"]] = m.metric.GetGauge().GetValue()
+	}
+
+	// Node pools not annotated by the machinedeploymentstatus resource yet do
+	// not expose the metric.
+	expected := map[string]float64{"a1b2c": 2}
+	if len(upgraded) != len(expected) || upgraded["a1b2c"] != expected["a1b2c"] {
+		t.Fatalf("upgraded nodes == %#v, want %#v", upgraded, expected)
+	}
+}
+
+type collectedMetric struct {
+	desc   string
+	metric *dto.Metric
+}
+
+// collectMetrics runs the given collect function and returns the written
+// metrics together with the string representation of their descriptions.
+func collectMetrics(t *testing.T, collect func(ch chan<- prometheus.Metric) error) []collectedMetric {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric, 100)
+	err := collect(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(ch)
+
+	var metrics []collectedMetric
+	for m := range ch {
+		var d dto.Metric
+		err = m.Write(&d)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		metrics = append(metrics, collectedMetric{desc: m.Desc().String(), metric: &d})
+	}
+
+	return metrics
+}
+
+func newTestMachineDeployment(id string, annotations map[string]string) *apiv1beta1.MachineDeployment {
+	return &apiv1beta1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        id,
+			Namespace:   "org-giantswarm",
+			Annotations: annotations,
+			Labels: map[string]string{
+				label.Cluster:           "8y5ck",
+				label.MachineDeployment: id,
+				label.OperatorVersion:   project.Version(),
+			},
+		},
+		Status: apiv1beta1.MachineDeploymentStatus{
+			Replicas:      3,
+			ReadyReplicas: 3,
+		},
+	}
+}
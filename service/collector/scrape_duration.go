@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/giantswarm/exporterkit/collector"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	subsystemCollector string = "collector"
+)
+
+var (
+	collectorScrapeDuration *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCollector, "scrape_duration_seconds"),
+		"Duration of the latest scrape of a collector.",
+		[]string{
+			"collector",
+		},
+		nil,
+	)
+)
+
+// scrapeDuration wraps a collector and exposes the duration of its scrapes.
+type scrapeDuration struct {
+	collector collector.Interface
+	name      string
+}
+
+func newScrapeDuration(name string, c collector.Interface) *scrapeDuration {
+	return &scrapeDuration{
+		collector: c,
+		name:      name,
+	}
+}
+
+func (s *scrapeDuration) Collect(ch chan<- prometheus.Metric) error {
+	start := time.Now()
+
+	// The duration is exposed for failed scrapes as well, so that slow
+	// failures are visible too.
+	err := s.collector.Collect(ch)
+
+	ch <- prometheus.MustNewConstMetric(
+		collectorScrapeDuration,
+		prometheus.GaugeValue,
+		time.Since(start).Seconds(),
+		s.name,
+	)
+
+	return err
+}
+
+func (s *scrapeDuration) Describe(ch chan<- *prometheus.Desc) error {
+	// Every wrapped collector describes the same descriptor, which the
+	// registry permits for descriptors of a single collector set.
+	ch <- collectorScrapeDuration
+
+	return s.collector.Describe(ch)
+}
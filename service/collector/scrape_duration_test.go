@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var testDesc = prometheus.NewDesc("cluster_operator_test_metric", "Test metric.", nil, nil)
+
+type fakeCollector struct {
+	err error
+}
+
+func (c fakeCollector) Collect(ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(testDesc, prometheus.GaugeValue, 1)
+	return c.err
+}
+
+func (c fakeCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- testDesc
+	return nil
+}
+
+func TestScrapeDuration(t *testing.T) {
+	collectors := []*scrapeDuration{
+		newScrapeDuration("cluster", fakeCollector{}),
+		newScrapeDuration("node_pool", fakeCollector{}),
+	}
+
+	durations := map[string]int{}
+	var metrics int
+	for _, c := range collectors {
+		for _, m := range collectMetrics(t, c.Collect) {
+			switch m.desc {
+			case collectorScrapeDuration.String():
+				for _, l := range m.metric.GetLabel() {
+					if l.GetName() == "collector" {
+						durations[l.GetValue()]++
+					}
+				}
+			case testDesc.String():
+				metrics++
+			}
+		}
+	}
+
+	if metrics != 2 {
+		t.Fatalf("metrics == %d, want %d", metrics, 2)
+	}
+	if len(durations) != 2 || durations["cluster"] != 1 || durations["node_pool"] != 1 {
+		t.Fatalf("scrape durations == %#v, want one per collector", durations)
+	}
+}
+
+func TestScrapeDurationError(t *testing.T) {
+	failure := errors.New("failure")
+	c := newScrapeDuration("cluster", fakeCollector{err: failure})
+
+	ch := make(chan prometheus.Metric, 10)
+	err := c.Collect(ch)
+	close(ch)
+
+	if !errors.Is(err, failure) {
+		t.Fatalf("error == %#v, want %#v", err, failure)
+	}
+
+	// The duration of failed scrapes is exposed as well.
+	var durations int
+	for m := range ch {
+		if m.Desc().String() == collectorScrapeDuration.String() {
+			durations++
+		}
+	}
+	if durations != 1 {
+		t.Fatalf("scrape durations == %d, want %d", durations, 1)
+	}
+}
@@ -1,21 +1,39 @@
 package collector
 
 import (
+	"context"
+	"time"
+
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	"github.com/giantswarm/certs/v4/pkg/certs"
 	"github.com/giantswarm/exporterkit/collector"
 	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
 )
 
 type SetConfig struct {
 	CertSearcher certs.Interface
+	Event        recorder.Interface
 	K8sClient    k8sclient.Interface
 	Logger       micrologger.Logger
 
+	CertificateExpiryThreshold time.Duration
 	NewCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	Provider                   string
+	TransitionDurations        *prometheus.HistogramVec
 }
 
 // Set is basically only a wrapper for the operator's collector implementations.
@@ -23,16 +41,72 @@ type SetConfig struct {
 // have to alias packages.
 type Set struct {
 	*collector.Set
+
+	cache   cache.Cache
+	logger  micrologger.Logger
+	objects []client.Object
 }
 
 func NewSet(config SetConfig) (*Set, error) {
 	var err error
 
+	// All collectors read from an informer backed cache, so that scrapes do
+	// not hit the API server and their duration does not grow with the number
+	// of clusters. Only the CRs reconciled by this operator version and the
+	// certificate Secrets are cached.
+	var objects []client.Object
+	var ctrlCache cache.Cache
+	{
+		selector := cache.ObjectSelector{
+			Label: labels.SelectorFromSet(labels.Set{label.OperatorVersion: project.Version()}),
+		}
+
+		var secretSelector cache.ObjectSelector
+		{
+			certificate, err := labels.NewRequirement(label.Certificate, selection.Exists, nil)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			cluster, err := labels.NewRequirement(label.Cluster, selection.Exists, nil)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			secretSelector = cache.ObjectSelector{
+				Label: labels.NewSelector().Add(*certificate, *cluster),
+			}
+		}
+
+		objects = []client.Object{
+			&apiv1beta1.Cluster{},
+			&apiv1beta1.MachineDeployment{},
+			&corev1.Secret{},
+		}
+		if config.Provider == label.ProviderAWS {
+			objects = append(objects, config.NewCommonClusterObjectFunc())
+		}
+
+		o := cache.Options{
+			Mapper: config.K8sClient.CtrlClient().RESTMapper(),
+			Scheme: config.K8sClient.Scheme(),
+			SelectorsByObject: cache.SelectorsByObject{
+				&apiv1beta1.Cluster{}:           selector,
+				&apiv1beta1.MachineDeployment{}: selector,
+				&corev1.Secret{}:                secretSelector,
+			},
+		}
+
+		ctrlCache, err = cache.New(config.K8sClient.RESTConfig(), o)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var clusterCollector *Cluster
 	{
 		c := ClusterConfig{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			Logger: config.Logger,
+			Reader: ctrlCache,
 
 			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 			Provider:                   config.Provider,
@@ -47,8 +121,8 @@ func NewSet(config SetConfig) (*Set, error) {
 	var nodePoolCollector *NodePool
 	{
 		c := NodePoolConfig{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			Logger: config.Logger,
+			Reader: ctrlCache,
 		}
 
 		nodePoolCollector, err = NewNodePool(c)
@@ -60,8 +134,9 @@ func NewSet(config SetConfig) (*Set, error) {
 	var clusterTransitionCollector *ClusterTransition
 	{
 		c := ClusterTransitionConfig{
-			K8sClient: config.K8sClient,
+			Durations: config.TransitionDurations,
 			Logger:    config.Logger,
+			Reader:    ctrlCache,
 
 			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 			Provider:                   config.Provider,
@@ -73,13 +148,30 @@ func NewSet(config SetConfig) (*Set, error) {
 		}
 	}
 
+	var certificateExpiryCollector *CertificateExpiry
+	{
+		c := CertificateExpiryConfig{
+			Event:  config.Event,
+			Logger: config.Logger,
+			Reader: ctrlCache,
+
+			Threshold: config.CertificateExpiryThreshold,
+		}
+
+		certificateExpiryCollector, err = NewCertificateExpiry(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var collectorSet *collector.Set
 	{
 		c := collector.SetConfig{
 			Collectors: []collector.Interface{
-				clusterCollector,
-				nodePoolCollector,
-				clusterTransitionCollector,
+				newScrapeDuration("cluster", clusterCollector),
+				newScrapeDuration("node_pool", nodePoolCollector),
+				newScrapeDuration("cluster_transition", clusterTransitionCollector),
+				newScrapeDuration("certificate_expiry", certificateExpiryCollector),
 			},
 			Logger: config.Logger,
 		}
@@ -92,7 +184,40 @@ func NewSet(config SetConfig) (*Set, error) {
 
 	s := &Set{
 		Set: collectorSet,
+
+		cache:   ctrlCache,
+		logger:  config.Logger,
+		objects: objects,
 	}
 
 	return s, nil
 }
+
+// Boot starts the informers of the cache and registers the collectors once
+// the cache is synced.
+func (s *Set) Boot(ctx context.Context) error {
+	for _, o := range s.objects {
+		_, err := s.cache.GetInformer(ctx, o)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	go func() {
+		err := s.cache.Start(ctx)
+		if err != nil {
+			s.logger.Errorf(ctx, err, "failed to start collector cache")
+		}
+	}()
+
+	if !s.cache.WaitForCacheSync(ctx) {
+		return microerror.Mask(cacheNotSyncedError)
+	}
+
+	err := s.Set.Boot(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
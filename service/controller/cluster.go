@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/giantswarm/apiextensions/v6/pkg/annotation"
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	"github.com/giantswarm/certs/v4/pkg/certs"
@@ -16,6 +18,7 @@ import (
 	"github.com/giantswarm/operatorkit/v8/pkg/resource/wrapper/retryresource"
 	"github.com/giantswarm/resource/v6/appresource"
 	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -29,23 +32,35 @@ import (
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/app"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/appfinalizer"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/appversionlabel"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/certbackend"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/certconfig"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/certmanager"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/certrotation"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/clusterconditions"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/clusterconfigmap"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/clusterdeleting"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/clusterid"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/clusterstatus"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/clustertransition"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/cpnamespace"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/deletecrs"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/deleteinfrarefs"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/keepforcrs"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/keepforinfrarefs"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/kubeconfig"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/kubeconfigcheck"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/meshid"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/oidckubeconfig"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/statuscondition"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/updateg8scontrolplanes"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/updateinfrarefs"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/updatemachinedeployments"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/certspec"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/hamaster"
+	internalmeshid "github.com/giantswarm/cluster-operator/v5/service/internal/meshid"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/podcidr"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/proxy"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/tenantclient"
@@ -54,28 +69,44 @@ import (
 // ClusterConfig contains necessary dependencies and settings for CAPI's Cluster
 // CRD controller implementation.
 type ClusterConfig struct {
-	BaseDomain     basedomain.Interface
-	CertsSearcher  certs.Interface
-	Event          recorder.Interface
-	FileSystem     afero.Fs
-	K8sClient      k8sclient.Interface
-	Logger         micrologger.Logger
-	PodCIDR        podcidr.Interface
-	Tenant         tenantcluster.Interface
-	ReleaseVersion releaseversion.Interface
+	BaseDomain          basedomain.Interface
+	CertsSearcher       certs.Interface
+	Event               recorder.Interface
+	FileSystem          afero.Fs
+	K8sClient           k8sclient.Interface
+	Logger              micrologger.Logger
+	PodCIDR             podcidr.Interface
+	Tenant              tenantcluster.Interface
+	ReleaseVersion      releaseversion.Interface
+	TransitionDurations *prometheus.HistogramVec
 
 	APIIP                      string
+	CATTL                      string
+	CertBackend                string
 	CertTTL                    string
 	ClusterIPRange             string
 	DNSIP                      string
 	ClusterDomain              string
+	CreationStuckThreshold     time.Duration
 	KiamWatchDogEnabled        bool
 	Installation               string
+	InstallationAPIEndpoint    string
+	InstallationRegion         string
+	KubeConfigMirrorNamespace  string
+	KubeConfigMirrorSelector   string
+	KubeConfigOIDCClientID     string
+	KubeConfigOIDCIssuerURL    string
+	MeshIDMax                  int
+	MeshIDMin                  int
+	MeshIDNamespace            string
 	NewCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	Provider                   string
 	RawAppDefaultConfig        string
 	RawAppOverrideConfig       string
+	RawKubeConfigVariants      string
 	RegistryDomain             string
+	RegistryMirrors            []string
+	UpgradeStuckThreshold      time.Duration
 }
 
 type Cluster struct {
@@ -141,6 +172,65 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	kubeConfigVariants, err := key.ParseKubeConfigVariants(config.RawKubeConfigVariants)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var certSpec certspec.Interface
+	{
+		c := certspec.Config{
+			BaseDomain: config.BaseDomain,
+			HAMaster:   haMaster,
+
+			KubeConfigVariants: kubeConfigVariants,
+
+			APIIP:         config.APIIP,
+			CATTL:         config.CATTL,
+			CertTTL:       config.CertTTL,
+			ClusterDomain: config.ClusterDomain,
+			Provider:      config.Provider,
+		}
+
+		certSpec, err = certspec.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var meshIDAllocator internalmeshid.Interface
+	{
+		c := internalmeshid.Config{
+			K8sClient: config.K8sClient,
+
+			Max:       config.MeshIDMax,
+			Min:       config.MeshIDMin,
+			Namespace: config.MeshIDNamespace,
+		}
+
+		meshIDAllocator, err = internalmeshid.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var proxySettings proxy.Interface
+	{
+		c := proxy.Config{
+			BaseDomain: config.BaseDomain,
+			K8sClient:  config.K8sClient,
+			PodCIDR:    config.PodCIDR,
+
+			ClusterDomain:  config.ClusterDomain,
+			ClusterIPRange: config.ClusterIPRange,
+		}
+
+		proxySettings, err = proxy.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var tenantClient tenantclient.Interface
 	{
 		c := tenantclient.Config{
@@ -166,6 +256,7 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 
 			Provider:             config.Provider,
 			KiamWatchDogEnabled:  config.KiamWatchDogEnabled,
+			KubeConfigVariants:   kubeConfigVariants,
 			RawAppDefaultConfig:  config.RawAppDefaultConfig,
 			RawAppOverrideConfig: config.RawAppOverrideConfig,
 		}
@@ -229,19 +320,29 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	var certBackendResource resource.Interface
+	{
+		c := certbackend.Config{
+			CtrlClient:     config.K8sClient.CtrlClient(),
+			Logger:         config.Logger,
+			ReleaseVersion: config.ReleaseVersion,
+
+			CertBackend: config.CertBackend,
+		}
+
+		certBackendResource, err = certbackend.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var certConfigResource resource.Interface
 	{
 		c := certconfig.Config{
-			BaseDomain:     config.BaseDomain,
+			CertSpec:       certSpec,
 			CtrlClient:     config.K8sClient.CtrlClient(),
-			HAMaster:       haMaster,
 			Logger:         config.Logger,
 			ReleaseVersion: config.ReleaseVersion,
-
-			APIIP:         config.APIIP,
-			CertTTL:       config.CertTTL,
-			ClusterDomain: config.ClusterDomain,
-			Provider:      config.Provider,
 		}
 
 		certConfigResource, err = certconfig.New(c)
@@ -250,19 +351,42 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
-	var clusterConfigMapGetter configmapresource.StateGetter
+	var certManagerResource resource.Interface
+	{
+		c := certmanager.Config{
+			BaseDomain: config.BaseDomain,
+			CertSpec:   certSpec,
+			CtrlClient: config.K8sClient.CtrlClient(),
+			Logger:     config.Logger,
+
+			CATTL: config.CATTL,
+		}
+
+		certManagerResource, err = certmanager.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterConfigMapGetter *clusterconfigmap.Resource
 	{
 		c := clusterconfigmap.Config{
 			BaseDomain: config.BaseDomain,
 			CtrlClient: config.K8sClient.CtrlClient(),
+			Event:      config.Event,
 			K8sClient:  config.K8sClient.K8sClient(),
 			Logger:     config.Logger,
 			PodCIDR:    config.PodCIDR,
+			Proxy:      proxySettings,
 
-			ClusterIPRange: config.ClusterIPRange,
-			DNSIP:          config.DNSIP,
-			Installation:   config.Installation,
-			Provider:       config.Provider,
+			ClusterIPRange:          config.ClusterIPRange,
+			DNSIP:                   config.DNSIP,
+			Installation:            config.Installation,
+			InstallationAPIEndpoint: config.InstallationAPIEndpoint,
+			InstallationRegion:      config.InstallationRegion,
+			Provider:                config.Provider,
+			RegistryDomain:          config.RegistryDomain,
+			RegistryMirrors:         config.RegistryMirrors,
 		}
 
 		clusterConfigMapGetter, err = clusterconfigmap.New(c)
@@ -295,6 +419,105 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	var clusterSecretValuesGetter secretresource.StateGetter
+	{
+		clusterSecretValuesGetter, err = clusterconfigmap.NewSecretStateGetter(clusterConfigMapGetter)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterSecretValuesResource resource.Interface
+	{
+		c := secretresource.Config{
+			K8sClient: config.K8sClient.K8sClient(),
+			Logger:    config.Logger,
+
+			Name:        clusterconfigmap.SecretName,
+			StateGetter: clusterSecretValuesGetter,
+		}
+
+		ops, err := secretresource.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		clusterSecretValuesResource, err = toCRUDResource(config.Logger, ops)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterConditionsResource resource.Interface
+	{
+		c := clusterconditions.Config{
+			CertSpec:   certSpec,
+			CtrlClient: config.K8sClient.CtrlClient(),
+			Event:      config.Event,
+			Logger:     config.Logger,
+
+			Provider: config.Provider,
+			Variants: kubeConfigVariants,
+		}
+
+		clusterConditionsResource, err = clusterconditions.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterDeletingResource resource.Interface
+	{
+		c := clusterdeleting.Config{
+			Event:     config.Event,
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+
+			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+			Provider:                   config.Provider,
+		}
+
+		clusterDeletingResource, err = clusterdeleting.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterDeletionDurationResource resource.Interface
+	{
+		c := clusterdeleting.Config{
+			Event:     config.Event,
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+
+			Durations:                  config.TransitionDurations,
+			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+			Provider:                   config.Provider,
+		}
+
+		clusterDeletionDurationResource, err = clusterdeleting.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterTransitionResource resource.Interface
+	{
+		c := clustertransition.Config{
+			Durations: config.TransitionDurations,
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+
+			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+			Provider:                   config.Provider,
+		}
+
+		clusterTransitionResource, err = clustertransition.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var clusterIDResource resource.Interface
 	{
 		c := clusterid.Config{
@@ -450,27 +673,15 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 
 	var kubeConfigGetter secretresource.StateGetter
 	{
-		var tenantCluster tenantcluster.Interface
-		{
-			c := tenantcluster.Config{
-				CertsSearcher: config.CertsSearcher,
-				Logger:        config.Logger,
-
-				CertID: certs.AppOperatorAPICert,
-			}
-
-			tenantCluster, err = tenantcluster.New(c)
-			if err != nil {
-				return nil, microerror.Mask(err)
-			}
-		}
-
 		c := kubeconfig.Config{
 			BaseDomain:    config.BaseDomain,
 			CertsSearcher: config.CertsSearcher,
 			K8sClient:     config.K8sClient.K8sClient(),
 			Logger:        config.Logger,
-			Tenant:        tenantCluster,
+
+			MirrorNamespace:         config.KubeConfigMirrorNamespace,
+			MirrorNamespaceSelector: config.KubeConfigMirrorSelector,
+			Variants:                kubeConfigVariants,
 		}
 
 		kubeConfigGetter, err = kubeconfig.New(c)
@@ -500,6 +711,91 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	var oidcKubeConfigGetter configmapresource.StateGetter
+	{
+		c := oidckubeconfig.Config{
+			BaseDomain:    config.BaseDomain,
+			CertsSearcher: config.CertsSearcher,
+			K8sClient:     config.K8sClient.K8sClient(),
+			Logger:        config.Logger,
+
+			ClientID:  config.KubeConfigOIDCClientID,
+			IssuerURL: config.KubeConfigOIDCIssuerURL,
+		}
+
+		oidcKubeConfigGetter, err = oidckubeconfig.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var oidcKubeConfigResource resource.Interface
+	{
+		c := configmapresource.Config{
+			K8sClient: config.K8sClient.K8sClient(),
+			Logger:    config.Logger,
+
+			Name:        oidckubeconfig.Name,
+			StateGetter: oidcKubeConfigGetter,
+		}
+
+		ops, err := configmapresource.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		oidcKubeConfigResource, err = toCRUDResource(config.Logger, ops)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var kubeConfigCheckResource resource.Interface
+	{
+		c := kubeconfigcheck.Config{
+			CtrlClient: config.K8sClient.CtrlClient(),
+			Logger:     config.Logger,
+
+			Timeout:  5 * time.Second,
+			Variants: kubeConfigVariants,
+		}
+
+		kubeConfigCheckResource, err = kubeconfigcheck.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var certRotationResource resource.Interface
+	{
+		c := certrotation.Config{
+			CtrlClient: config.K8sClient.CtrlClient(),
+			Event:      config.Event,
+			Logger:     config.Logger,
+
+			KubeConfigVariants: kubeConfigVariants,
+		}
+
+		certRotationResource, err = certrotation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var meshIDResource resource.Interface
+	{
+		c := meshid.Config{
+			CtrlClient: config.K8sClient.CtrlClient(),
+			Logger:     config.Logger,
+			MeshID:     meshIDAllocator,
+		}
+
+		meshIDResource, err = meshid.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var statusConditionResource resource.Interface
 	{
 		c := statuscondition.Config{
@@ -509,8 +805,10 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 			ReleaseVersion: config.ReleaseVersion,
 			TenantClient:   tenantClient,
 
+			CreationStuckThreshold:     config.CreationStuckThreshold,
 			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 			Provider:                   config.Provider,
+			UpgradeStuckThreshold:      config.UpgradeStuckThreshold,
 		}
 
 		statusConditionResource, err = statuscondition.New(c)
@@ -564,11 +862,26 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 	}
 
 	resources := []resource.Interface{
+		// Following resource marks the cluster as deleting before any other
+		// resource acts on the deletion.
+		clusterDeletingResource,
+
+		// Following resource observes completed cluster transitions before
+		// any other resource may cancel the reconciliation.
+		clusterTransitionResource,
+
 		// Following resources manage resources in the control plane.
 		cpNamespaceResource,
+		certBackendResource,
 		certConfigResource,
+		certManagerResource,
+		meshIDResource,
 		clusterConfigMapResource,
+		clusterSecretValuesResource,
 		kubeConfigResource,
+		kubeConfigCheckResource,
+		oidcKubeConfigResource,
+		certRotationResource,
 		appResource,
 		appFinalizerResource,
 		appVersionLabelResource,
@@ -579,6 +892,7 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		// Following resources manage CR status information.
 		clusterIDResource,
 		clusterStatusResource,
+		clusterConditionsResource,
 		statusConditionResource,
 
 		// Following resources manage tenant cluster deletion events.
@@ -588,6 +902,10 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		keepForG8sControlPlaneCRsResource,
 		keepForMachineDeploymentCRsResource,
 		keepForInfraRefsResource,
+
+		// Following resource observes the deletion duration once no resource
+		// above keeps the finalizers anymore.
+		clusterDeletionDurationResource,
 	}
 
 	// Wrap resources with retry and metrics.
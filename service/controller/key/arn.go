@@ -0,0 +1,101 @@
+package key
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// PartitionAWS is the partition of the standard AWS regions.
+	PartitionAWS = "aws"
+	// PartitionAWSChina is the partition of the AWS China regions.
+	PartitionAWSChina = "aws-cn"
+	// PartitionAWSGovCloud is the partition of the AWS GovCloud (US) regions.
+	PartitionAWSGovCloud = "aws-us-gov"
+)
+
+// AWSOperatorRoleARNKey is the key of the aws-operator role ARN in the AWS
+// credential Secrets.
+const AWSOperatorRoleARNKey = "aws.awsoperator.arn"
+
+var accountIDRegexp = regexp.MustCompile(`^\d{12}$`)
+
+// ARN is the parsed representation of an AWS Amazon Resource Name in the
+// format arn:partition:service:region:account-id:resource.
+type ARN struct {
+	Partition string
+	Service   string
+	Region    string
+	AccountID string
+	Resource  string
+}
+
+// String returns the ARN in its canonical string representation.
+func (a ARN) String() string {
+	return strings.Join([]string{"arn", a.Partition, a.Service, a.Region, a.AccountID, a.Resource}, ":")
+}
+
+// ParseARN parses and validates the given ARN. The partition must be one of
+// the known AWS partitions and the account ID must consist of 12 digits.
+func ParseARN(s string) (ARN, error) {
+	// The resource part may contain colons itself, e.g. for IAM paths or
+	// qualified Lambda functions, so we only split off the first 5 sections.
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return ARN{}, microerror.Maskf(invalidARNError, "ARN %#q must have the format arn:partition:service:region:account-id:resource", s)
+	}
+
+	a := ARN{
+		Partition: parts[1],
+		Service:   parts[2],
+		Region:    parts[3],
+		AccountID: parts[4],
+		Resource:  parts[5],
+	}
+
+	if !isKnownPartition(a.Partition) {
+		return ARN{}, microerror.Maskf(invalidARNError, "ARN %#q has unknown partition %#q", s, a.Partition)
+	}
+	if a.Service == "" {
+		return ARN{}, microerror.Maskf(invalidARNError, "ARN %#q must not have an empty service", s)
+	}
+	if !accountIDRegexp.MatchString(a.AccountID) {
+		return ARN{}, microerror.Maskf(invalidARNError, "ARN %#q must have a 12 digit account ID", s)
+	}
+	if a.Resource == "" {
+		return ARN{}, microerror.Maskf(invalidARNError, "ARN %#q must not have an empty resource", s)
+	}
+
+	return a, nil
+}
+
+// RoleARN returns the ARN of the IAM role with the given name in the given
+// partition and account.
+func RoleARN(partition string, accountID string, role string) string {
+	a := ARN{
+		Partition: partition,
+		Service:   "iam",
+		AccountID: accountID,
+		Resource:  fmt.Sprintf("role/%s", role),
+	}
+
+	return a.String()
+}
+
+// Route53ManagerRoleARN returns the ARN of the IAM role external-dns assumes
+// to manage the Route53 records of the given tenant cluster.
+func Route53ManagerRoleARN(getter LabelsGetter, partition string, accountID string) string {
+	return RoleARN(partition, accountID, fmt.Sprintf("%s-Route53Manager-Role", ClusterID(getter)))
+}
+
+func isKnownPartition(partition string) bool {
+	switch partition {
+	case PartitionAWS, PartitionAWSChina, PartitionAWSGovCloud:
+		return true
+	}
+
+	return false
+}
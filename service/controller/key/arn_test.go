@@ -0,0 +1,125 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+)
+
+func Test_ParseARN(t *testing.T) {
+	testCases := []struct {
+		description  string
+		input        string
+		expected     ARN
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "aws partition",
+			input:       "arn:aws:iam::123456789012:role/GiantSwarmAWSOperator",
+			expected: ARN{
+				Partition: "aws",
+				Service:   "iam",
+				Region:    "",
+				AccountID: "123456789012",
+				Resource:  "role/GiantSwarmAWSOperator",
+			},
+		},
+		{
+			description: "aws-cn partition",
+			input:       "arn:aws-cn:iam::123456789012:role/GiantSwarmAWSOperator",
+			expected: ARN{
+				Partition: "aws-cn",
+				Service:   "iam",
+				Region:    "",
+				AccountID: "123456789012",
+				Resource:  "role/GiantSwarmAWSOperator",
+			},
+		},
+		{
+			description: "aws-us-gov partition with region and colon in resource",
+			input:       "arn:aws-us-gov:lambda:us-gov-west-1:123456789012:function:my-function:1",
+			expected: ARN{
+				Partition: "aws-us-gov",
+				Service:   "lambda",
+				Region:    "us-gov-west-1",
+				AccountID: "123456789012",
+				Resource:  "function:my-function:1",
+			},
+		},
+		{
+			description:  "error, empty string",
+			input:        "",
+			errorMatcher: IsInvalidARN,
+		},
+		{
+			description:  "error, missing arn prefix",
+			input:        "aws:iam::123456789012:role/GiantSwarmAWSOperator",
+			errorMatcher: IsInvalidARN,
+		},
+		{
+			description:  "error, unknown partition",
+			input:        "arn:aws-foo:iam::123456789012:role/GiantSwarmAWSOperator",
+			errorMatcher: IsInvalidARN,
+		},
+		{
+			description:  "error, short account ID",
+			input:        "arn:aws:iam::12345:role/GiantSwarmAWSOperator",
+			errorMatcher: IsInvalidARN,
+		},
+		{
+			description:  "error, empty resource",
+			input:        "arn:aws:iam::123456789012:",
+			errorMatcher: IsInvalidARN,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := ParseARN(tc.input)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("ARN %#v doesn't match expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_Route53ManagerRoleARN(t *testing.T) {
+	testCases := []struct {
+		description string
+		partition   string
+		expected    string
+	}{
+		{
+			description: "aws partition",
+			partition:   PartitionAWS,
+			expected:    "arn:aws:iam::123456789012:role/w7utg-Route53Manager-Role",
+		},
+		{
+			description: "aws-cn partition",
+			partition:   PartitionAWSChina,
+			expected:    "arn:aws-cn:iam::123456789012:role/w7utg-Route53Manager-Role",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual := Route53ManagerRoleARN(&testObject{map[string]string{label.Cluster: "w7utg"}}, tc.partition, "123456789012")
+			if actual != tc.expected {
+				t.Fatalf("role ARN %#q doesn't match expected %#q", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -2,6 +2,15 @@ package key
 
 import (
 	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
 )
 
 const (
@@ -23,3 +32,110 @@ func CertDefaultAltNames(clusterDomain string) []string {
 func CertConfigName(getter LabelsGetter, name string) string {
 	return fmt.Sprintf("%s-%s", ClusterID(getter), name)
 }
+
+// CertTTLOverrides returns the certificate TTLs configured via annotations on
+// the Cluster CR, indexed by cluster component. Per component annotations take
+// precedence over the cluster wide annotation. Components without configured
+// TTL are not part of the returned map. All TTLs must be lower than the given
+// CA TTL. Annotations for components not listed in components are rejected.
+func CertTTLOverrides(getter AnnotationsGetter, components []string, caTTL time.Duration) (map[string]string, error) {
+	annotations := getter.GetAnnotations()
+
+	known := map[string]bool{}
+	for _, c := range components {
+		known[c] = true
+	}
+
+	overrides := map[string]string{}
+
+	if v, ok := annotations[annotation.CertTTL]; ok {
+		err := validateCertTTL(annotation.CertTTL, v, caTTL)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, c := range components {
+			overrides[c] = v
+		}
+	}
+
+	for k, v := range annotations {
+		if !strings.HasPrefix(k, annotation.CertTTLPrefix) {
+			continue
+		}
+
+		c := strings.TrimPrefix(k, annotation.CertTTLPrefix)
+		if !known[c] {
+			return nil, microerror.Maskf(invalidAnnotationError, "annotation %#q refers to unknown certificate %#q", k, c)
+		}
+
+		err := validateCertTTL(k, v, caTTL)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		overrides[c] = v
+	}
+
+	return overrides, nil
+}
+
+// APICertAltNames returns the additional DNS names of the API certificate
+// configured via annotation on the Cluster CR in sorted order.
+func APICertAltNames(getter AnnotationsGetter) ([]string, error) {
+	names := splitList(getter.GetAnnotations()[annotation.APICertAltNames])
+
+	for _, n := range names {
+		// Wildcard names are allowed for the leftmost label only.
+		errs := validation.IsDNS1123Subdomain(strings.TrimPrefix(n, "*."))
+		if len(errs) > 0 {
+			return nil, microerror.Maskf(invalidAnnotationError, "annotation %#q name %#q is not a valid DNS name: %s", annotation.APICertAltNames, n, strings.Join(errs, ", "))
+		}
+	}
+
+	return names, nil
+}
+
+// APICertIPSANs returns the additional IP addresses of the API certificate
+// configured via annotation on the Cluster CR in sorted order.
+func APICertIPSANs(getter AnnotationsGetter) ([]string, error) {
+	ips := splitList(getter.GetAnnotations()[annotation.APICertIPSANs])
+
+	for _, ip := range ips {
+		if net.ParseIP(ip) == nil {
+			return nil, microerror.Maskf(invalidAnnotationError, "annotation %#q value %#q is not a valid IP address", annotation.APICertIPSANs, ip)
+		}
+	}
+
+	return ips, nil
+}
+
+func splitList(s string) []string {
+	l := []string{}
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		l = append(l, v)
+	}
+	sort.Strings(l)
+
+	return l
+}
+
+func validateCertTTL(name, value string, caTTL time.Duration) error {
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return microerror.Maskf(invalidAnnotationError, "annotation %#q must be a duration, got %#q", name, value)
+	}
+	if ttl <= 0 {
+		return microerror.Maskf(invalidAnnotationError, "annotation %#q must be positive, got %#q", name, value)
+	}
+	if ttl >= caTTL {
+		return microerror.Maskf(invalidAnnotationError, "annotation %#q must be lower than the CA TTL %s, got %#q", name, caTTL, value)
+	}
+
+	return nil
+}
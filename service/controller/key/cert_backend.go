@@ -0,0 +1,63 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+const (
+	// CertBackendCertConfig issues certificates via CertConfig CRs reconciled
+	// by the Vault backed cert-operator.
+	CertBackendCertConfig = "certconfig"
+	// CertBackendCertManager issues certificates via cert-manager Certificate
+	// CRs signed by a per cluster CA Issuer.
+	CertBackendCertManager = "cert-manager"
+)
+
+// CertBackend returns the certificate backend recorded on the Cluster CR of a
+// tenant cluster. It is empty as long as no backend was recorded yet.
+func CertBackend(getter AnnotationsGetter) string {
+	return getter.GetAnnotations()[annotation.CertBackend]
+}
+
+// InitialCertBackend returns the certificate backend of a new tenant cluster.
+// Releases which do not ship cert-operator anymore always use cert-manager.
+// All other releases use the installation wide default backend.
+func InitialCertBackend(defaultBackend string, certOperatorVersion string) string {
+	if certOperatorVersion == "" {
+		return CertBackendCertManager
+	}
+
+	return defaultBackend
+}
+
+// IsValidCertBackend checks whether the given certificate backend is known.
+func IsValidCertBackend(backend string) bool {
+	return backend == CertBackendCertConfig || backend == CertBackendCertManager
+}
+
+// CertManagerSelfSignedIssuerName returns the name of the cert-manager Issuer
+// bootstrapping the CA of the given tenant cluster.
+func CertManagerSelfSignedIssuerName(getter LabelsGetter) string {
+	return fmt.Sprintf("%s-selfsigned", ClusterID(getter))
+}
+
+// CertManagerCAName returns the name of the cert-manager CA Certificate, its
+// Secret and the CA Issuer signing all certificates of the given tenant
+// cluster.
+func CertManagerCAName(getter LabelsGetter) string {
+	return fmt.Sprintf("%s-ca", ClusterID(getter))
+}
+
+// CertManagerTLSSecretName returns the name of the Secret cert-manager writes
+// the given certificate to.
+func CertManagerTLSSecretName(getter LabelsGetter, component string) string {
+	return fmt.Sprintf("%s-%s-tls", ClusterID(getter), component)
+}
+
+// CertManagerCertsSecretName returns the name of the Secret holding the given
+// certificate issued by cert-manager in the format of the certs searcher.
+func CertManagerCertsSecretName(getter LabelsGetter, component string) string {
+	return fmt.Sprintf("%s-%s-certs", ClusterID(getter), component)
+}
@@ -0,0 +1,41 @@
+package key
+
+import (
+	"testing"
+)
+
+func Test_InitialCertBackend(t *testing.T) {
+	testCases := []struct {
+		description         string
+		defaultBackend      string
+		certOperatorVersion string
+		expected            string
+	}{
+		{
+			description:         "default certconfig backend",
+			defaultBackend:      CertBackendCertConfig,
+			certOperatorVersion: "3.0.1",
+			expected:            CertBackendCertConfig,
+		},
+		{
+			description:         "default cert-manager backend",
+			defaultBackend:      CertBackendCertManager,
+			certOperatorVersion: "3.0.1",
+			expected:            CertBackendCertManager,
+		},
+		{
+			description:    "release without cert-operator",
+			defaultBackend: CertBackendCertConfig,
+			expected:       CertBackendCertManager,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual := InitialCertBackend(tc.defaultBackend, tc.certOperatorVersion)
+			if actual != tc.expected {
+				t.Fatalf("backend %#q doesn't match expected %#q", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package key
+
+import (
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+const (
+	// CertificatesRotatedCondition is set on the Cluster CR to report the
+	// progress of the certificate rotation requested via annotation. It is
+	// false while the rotation is in progress and true once all certificates
+	// and the kubeconfig have been reissued.
+	CertificatesRotatedCondition apiv1beta1.ConditionType = "CertificatesRotated"
+
+	// CertificatesRotationInProgressReason is the reason of the
+	// CertificatesRotatedCondition while certificates are being reissued.
+	CertificatesRotationInProgressReason = "RotationInProgress"
+)
+
+// CertificatesRotationRequested returns the certificate rotation generation
+// requested via annotation on the Cluster CR, if any.
+func CertificatesRotationRequested(getter AnnotationsGetter) string {
+	return getter.GetAnnotations()[annotation.RotateCertificates]
+}
+
+// CertificatesRotationGeneration returns the certificate rotation generation
+// the operator last started rotating certificates for.
+func CertificatesRotationGeneration(getter AnnotationsGetter) string {
+	return getter.GetAnnotations()[annotation.CertificatesRotated]
+}
+
+// CertConfigRotationGeneration returns the certificate rotation generation the
+// given CertConfig CR was last rotated for.
+func CertConfigRotationGeneration(getter AnnotationsGetter) string {
+	return getter.GetAnnotations()[annotation.RotationGeneration]
+}
@@ -0,0 +1,178 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+func Test_CertTTLOverrides(t *testing.T) {
+	testCases := []struct {
+		description  string
+		annotations  map[string]string
+		expected     map[string]string
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "no annotations",
+			expected:    map[string]string{},
+		},
+		{
+			description: "cluster wide TTL",
+			annotations: map[string]string{
+				annotation.CertTTL: "720h",
+			},
+			expected: map[string]string{
+				"api":    "720h",
+				"etcd":   "720h",
+				"worker": "720h",
+			},
+		},
+		{
+			description: "per component TTL takes precedence",
+			annotations: map[string]string{
+				annotation.CertTTL:                "720h",
+				annotation.CertTTLPrefix + "etcd": "8760h",
+			},
+			expected: map[string]string{
+				"api":    "720h",
+				"etcd":   "8760h",
+				"worker": "720h",
+			},
+		},
+		{
+			description: "per component TTL only",
+			annotations: map[string]string{
+				annotation.CertTTLPrefix + "api": "24h",
+			},
+			expected: map[string]string{
+				"api": "24h",
+			},
+		},
+		{
+			description: "error, unknown component",
+			annotations: map[string]string{
+				annotation.CertTTLPrefix + "foo": "24h",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, invalid duration",
+			annotations: map[string]string{
+				annotation.CertTTL: "30d",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, negative duration",
+			annotations: map[string]string{
+				annotation.CertTTLPrefix + "api": "-24h",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, TTL not lower than CA TTL",
+			annotations: map[string]string{
+				annotation.CertTTL: "87600h",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tc.annotations}
+
+			actual, err := CertTTLOverrides(obj, []string{"api", "etcd", "worker"}, 87600*time.Hour)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher == nil && !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("overrides %#v don't match expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_APICertSANs(t *testing.T) {
+	testCases := []struct {
+		description      string
+		annotations      map[string]string
+		expectedAltNames []string
+		expectedIPSANs   []string
+		errorMatcher     func(error) bool
+	}{
+		{
+			description:      "no annotations",
+			expectedAltNames: []string{},
+			expectedIPSANs:   []string{},
+		},
+		{
+			description: "alt names and IP SANs",
+			annotations: map[string]string{
+				annotation.APICertAltNames: "api.example.com, *.internal.example.com",
+				annotation.APICertIPSANs:   "10.0.0.10,fd00::1",
+			},
+			expectedAltNames: []string{"*.internal.example.com", "api.example.com"},
+			expectedIPSANs:   []string{"10.0.0.10", "fd00::1"},
+		},
+		{
+			description: "error, invalid alt name",
+			annotations: map[string]string{
+				annotation.APICertAltNames: "api_example.com",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, invalid IP SAN",
+			annotations: map[string]string{
+				annotation.APICertIPSANs: "10.0.0.300",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tc.annotations}
+
+			altNames, err := APICertAltNames(obj)
+			if err == nil {
+				var ipSANs []string
+				ipSANs, err = APICertIPSANs(obj)
+				if err == nil {
+					if !reflect.DeepEqual(altNames, tc.expectedAltNames) {
+						t.Fatalf("alt names %#v don't match expected %#v", altNames, tc.expectedAltNames)
+					}
+					if !reflect.DeepEqual(ipSANs, tc.expectedIPSANs) {
+						t.Fatalf("IP SANs %#v don't match expected %#v", ipSANs, tc.expectedIPSANs)
+					}
+				}
+			}
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package key
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/giantswarm/microerror"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+const (
+	// CiliumENIOptionsSupportedCondition is set on the Cluster CR to report
+	// whether the Cilium ENI options configured via annotations are supported
+	// by the release of the tenant cluster.
+	CiliumENIOptionsSupportedCondition apiv1beta1.ConditionType = "CiliumENIOptionsSupported"
+
+	// CiliumENIOptionsInvalidReason is the reason of the
+	// CiliumENIOptionsSupportedCondition in case an annotation cannot be
+	// parsed.
+	CiliumENIOptionsInvalidReason = "InvalidOptions"
+	// CiliumENIOptionsUnsupportedReason is the reason of the
+	// CiliumENIOptionsSupportedCondition in case the options are not supported
+	// by the release.
+	CiliumENIOptionsUnsupportedReason = "UnsupportedOptions"
+)
+
+var (
+	// ciliumPrefixDelegationMinVersion is the first Cilium version with
+	// working prefix delegation in ENI mode.
+	ciliumPrefixDelegationMinVersion = semver.MustParse("1.13.0")
+	// ciliumENITagsMinVersion is the first Cilium version supporting subnet
+	// and security group tags in ENI mode.
+	ciliumENITagsMinVersion = semver.MustParse("1.12.0")
+)
+
+// CiliumENIOptions holds the per cluster settings of Cilium in ENI mode.
+type CiliumENIOptions struct {
+	PrefixDelegation  bool
+	PreAllocate       int
+	ReleaseExcessIPs  bool
+	SecurityGroupTags map[string]string
+	SubnetTags        map[string]string
+}
+
+// DefaultCiliumENIOptions returns the options used when no annotations are
+// set on the Cluster CR.
+func DefaultCiliumENIOptions() CiliumENIOptions {
+	return CiliumENIOptions{
+		ReleaseExcessIPs: true,
+	}
+}
+
+// CiliumENIOptionsFromCluster parses the Cilium ENI annotations of the given
+// Cluster CR. Annotations which are not set keep their default value, except
+// for the release of excess IPs, which defaults to false with prefix
+// delegation.
+func CiliumENIOptionsFromCluster(cluster apiv1beta1.Cluster) (CiliumENIOptions, error) {
+	o := DefaultCiliumENIOptions()
+	var err error
+
+	if v, ok := cluster.Annotations[annotation.CiliumENIPrefixDelegation]; ok {
+		o.PrefixDelegation, err = strconv.ParseBool(v)
+		if err != nil {
+			return CiliumENIOptions{}, microerror.Maskf(invalidAnnotationError, "annotation %#q must be a boolean", annotation.CiliumENIPrefixDelegation)
+		}
+	}
+	if v, ok := cluster.Annotations[annotation.CiliumENIPreAllocate]; ok {
+		o.PreAllocate, err = strconv.Atoi(v)
+		if err != nil || o.PreAllocate < 0 {
+			return CiliumENIOptions{}, microerror.Maskf(invalidAnnotationError, "annotation %#q must be a non-negative integer", annotation.CiliumENIPreAllocate)
+		}
+	}
+	if v, ok := cluster.Annotations[annotation.CiliumENIReleaseExcessIPs]; ok {
+		o.ReleaseExcessIPs, err = strconv.ParseBool(v)
+		if err != nil {
+			return CiliumENIOptions{}, microerror.Maskf(invalidAnnotationError, "annotation %#q must be a boolean", annotation.CiliumENIReleaseExcessIPs)
+		}
+	} else if o.PrefixDelegation {
+		// Releasing excess IPs does not work with prefix delegation, so it is
+		// only rejected when explicitly enabled.
+		o.ReleaseExcessIPs = false
+	}
+	if v, ok := cluster.Annotations[annotation.CiliumENISecurityGroupTags]; ok {
+		o.SecurityGroupTags, err = parseTags(annotation.CiliumENISecurityGroupTags, v)
+		if err != nil {
+			return CiliumENIOptions{}, microerror.Mask(err)
+		}
+	}
+	if v, ok := cluster.Annotations[annotation.CiliumENISubnetTags]; ok {
+		o.SubnetTags, err = parseTags(annotation.CiliumENISubnetTags, v)
+		if err != nil {
+			return CiliumENIOptions{}, microerror.Mask(err)
+		}
+	}
+
+	return o, nil
+}
+
+// ValidateCiliumENIOptions checks the given options against the Cilium
+// version shipped with the release of the tenant cluster.
+func ValidateCiliumENIOptions(o CiliumENIOptions, ciliumVersion string) error {
+	if o.PrefixDelegation && o.ReleaseExcessIPs {
+		return microerror.Maskf(unsupportedCiliumENIOptionsError, "prefix delegation cannot be combined with releasing excess IPs, set %#q to false", annotation.CiliumENIReleaseExcessIPs)
+	}
+
+	tags := len(o.SecurityGroupTags) > 0 || len(o.SubnetTags) > 0
+	if !o.PrefixDelegation && !tags {
+		// Nothing depends on the Cilium version.
+		return nil
+	}
+
+	v, err := semver.ParseTolerant(ciliumVersion)
+	if err != nil {
+		return microerror.Maskf(unsupportedCiliumENIOptionsError, "cannot determine cilium version of release from %#q", ciliumVersion)
+	}
+
+	if o.PrefixDelegation && v.LT(ciliumPrefixDelegationMinVersion) {
+		return microerror.Maskf(unsupportedCiliumENIOptionsError, "prefix delegation requires cilium %s or newer, release has %s", ciliumPrefixDelegationMinVersion, v)
+	}
+	if tags && v.LT(ciliumENITagsMinVersion) {
+		return microerror.Maskf(unsupportedCiliumENIOptionsError, "subnet and security group tags require cilium %s or newer, release has %s", ciliumENITagsMinVersion, v)
+	}
+
+	return nil
+}
+
+// CiliumENITagsList returns the given tags as sorted list of key=value pairs
+// as expected by the Cilium chart.
+func CiliumENITagsList(tags map[string]string) []string {
+	l := []string{}
+	for k, v := range tags {
+		l = append(l, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(l)
+
+	return l
+}
+
+func parseTags(name, s string) (map[string]string, error) {
+	tags := map[string]string{}
+	for _, t := range strings.Split(s, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+
+		k, v, ok := strings.Cut(t, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, microerror.Maskf(invalidAnnotationError, "annotation %#q tag %#q must be of the form key=value", name, t)
+		}
+
+		tags[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,175 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+func Test_CiliumENIOptionsFromCluster(t *testing.T) {
+	testCases := []struct {
+		description  string
+		annotations  map[string]string
+		expected     CiliumENIOptions
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "defaults",
+			expected: CiliumENIOptions{
+				ReleaseExcessIPs: true,
+			},
+		},
+		{
+			description: "all options",
+			annotations: map[string]string{
+				annotation.CiliumENIPrefixDelegation:  "true",
+				annotation.CiliumENIPreAllocate:       "8",
+				annotation.CiliumENIReleaseExcessIPs:  "false",
+				annotation.CiliumENISecurityGroupTags: "role=nodes",
+				annotation.CiliumENISubnetTags:        "type=pods, cluster=w7utg",
+			},
+			expected: CiliumENIOptions{
+				PrefixDelegation:  true,
+				PreAllocate:       8,
+				ReleaseExcessIPs:  false,
+				SecurityGroupTags: map[string]string{"role": "nodes"},
+				SubnetTags:        map[string]string{"cluster": "w7utg", "type": "pods"},
+			},
+		},
+		{
+			description: "prefix delegation without release of excess IPs",
+			annotations: map[string]string{
+				annotation.CiliumENIPrefixDelegation: "true",
+			},
+			expected: CiliumENIOptions{
+				PrefixDelegation: true,
+				ReleaseExcessIPs: false,
+			},
+		},
+		{
+			description: "prefix delegation with explicit release of excess IPs",
+			annotations: map[string]string{
+				annotation.CiliumENIPrefixDelegation: "true",
+				annotation.CiliumENIReleaseExcessIPs: "true",
+			},
+			expected: CiliumENIOptions{
+				PrefixDelegation: true,
+				ReleaseExcessIPs: true,
+			},
+		},
+		{
+			description: "error, invalid boolean",
+			annotations: map[string]string{
+				annotation.CiliumENIPrefixDelegation: "yes please",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, negative pre-allocate",
+			annotations: map[string]string{
+				annotation.CiliumENIPreAllocate: "-1",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, tag without value separator",
+			annotations: map[string]string{
+				annotation.CiliumENISubnetTags: "type",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cluster := apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+			}
+
+			actual, err := CiliumENIOptionsFromCluster(cluster)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("options %#v doesn't match expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_ValidateCiliumENIOptions(t *testing.T) {
+	testCases := []struct {
+		description   string
+		options       CiliumENIOptions
+		ciliumVersion string
+		errorMatcher  func(error) bool
+	}{
+		{
+			description:   "defaults without cilium version",
+			options:       DefaultCiliumENIOptions(),
+			ciliumVersion: "",
+		},
+		{
+			description: "prefix delegation on supported version",
+			options: CiliumENIOptions{
+				PrefixDelegation: true,
+			},
+			ciliumVersion: "1.13.4",
+		},
+		{
+			description: "error, prefix delegation on old version",
+			options: CiliumENIOptions{
+				PrefixDelegation: true,
+			},
+			ciliumVersion: "1.12.9",
+			errorMatcher:  IsUnsupportedCiliumENIOptions,
+		},
+		{
+			description: "error, prefix delegation with release of excess IPs",
+			options: CiliumENIOptions{
+				PrefixDelegation: true,
+				ReleaseExcessIPs: true,
+			},
+			ciliumVersion: "1.14.0",
+			errorMatcher:  IsUnsupportedCiliumENIOptions,
+		},
+		{
+			description: "error, subnet tags without cilium version",
+			options: CiliumENIOptions{
+				SubnetTags: map[string]string{"type": "pods"},
+			},
+			ciliumVersion: "",
+			errorMatcher:  IsUnsupportedCiliumENIOptions,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateCiliumENIOptions(tc.options, tc.ciliumVersion)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
@@ -11,10 +11,18 @@ func APIEndpoint(getter LabelsGetter, base string) string {
 	return fmt.Sprintf("api.%s.k8s.%s", ClusterID(getter), base)
 }
 
+func InternalAPIEndpoint(getter LabelsGetter, base string) string {
+	return fmt.Sprintf("internal-api.%s.k8s.%s", ClusterID(getter), base)
+}
+
 func KubeConfigEndpoint(getter LabelsGetter, base string) string {
 	return fmt.Sprintf("https://%s", APIEndpoint(getter, base))
 }
 
+func KubeConfigInternalEndpoint(getter LabelsGetter, base string) string {
+	return fmt.Sprintf("https://%s", InternalAPIEndpoint(getter, base))
+}
+
 func TenantEndpoint(getter LabelsGetter, base string) string {
 	return fmt.Sprintf("%s.k8s.%s", ClusterID(getter), base)
 }
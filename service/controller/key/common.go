@@ -27,6 +27,12 @@ func ClusterConfigMapName(getter LabelsGetter) string {
 	return fmt.Sprintf("%s-cluster-values", ClusterID(getter))
 }
 
+// ClusterSecretValuesName returns the name of the Secret holding the sensitive
+// part of the cluster values generated for this tenant cluster.
+func ClusterSecretValuesName(getter LabelsGetter) string {
+	return fmt.Sprintf("%s-cluster-secret-values", ClusterID(getter))
+}
+
 func ClusterID(getter LabelsGetter) string {
 	return getter.GetLabels()[label.Cluster]
 }
@@ -55,10 +61,6 @@ func KubeConfigClusterName(getter LabelsGetter) string {
 	return fmt.Sprintf("giantswarm-%s", ClusterID(getter))
 }
 
-func KubeConfigSecretName(getter LabelsGetter) string {
-	return fmt.Sprintf("%s-kubeconfig", ClusterID(getter))
-}
-
 func MachineDeployment(getter LabelsGetter) string {
 	return getter.GetLabels()[label.MachineDeployment]
 }
@@ -67,10 +69,21 @@ func OperatorVersion(getter LabelsGetter) string {
 	return getter.GetLabels()[label.OperatorVersion]
 }
 
+func OIDCKubeConfigConfigMapName(getter LabelsGetter) string {
+	return fmt.Sprintf("%s-oidc-kubeconfig", ClusterID(getter))
+}
+
 func OrganizationID(getter LabelsGetter) string {
 	return getter.GetLabels()[label.Organization]
 }
 
+// ProxyConfigSecretName returns the name of the Secret users create in the
+// namespace of the Cluster CR to configure the HTTP proxy of this tenant
+// cluster.
+func ProxyConfigSecretName(getter LabelsGetter) string {
+	return fmt.Sprintf("%s-proxy-config", ClusterID(getter))
+}
+
 func ReleaseName(releaseVersion string) string {
 	return fmt.Sprintf("v%s", releaseVersion)
 }
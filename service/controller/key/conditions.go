@@ -0,0 +1,97 @@
+package key
+
+import (
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// AppsReadyCondition is set on the Cluster CR once all apps of the tenant
+	// cluster are deployed.
+	AppsReadyCondition apiv1beta1.ConditionType = "AppsReady"
+	// CertificatesReadyCondition is set on the Cluster CR once all
+	// certificates of the tenant cluster are issued.
+	CertificatesReadyCondition apiv1beta1.ConditionType = "CertificatesReady"
+	// ClusterValuesReadyCondition is set on the Cluster CR once the cluster
+	// values ConfigMap and Secret apps are configured with exist.
+	ClusterValuesReadyCondition apiv1beta1.ConditionType = "ClusterValuesReady"
+	// KubeconfigReadyCondition is set on the Cluster CR once the kubeconfig
+	// Secrets of all kubeconfig variants exist.
+	KubeconfigReadyCondition apiv1beta1.ConditionType = "KubeconfigReady"
+	// NodesUpToDateCondition is set on the Cluster CR once all nodes of all
+	// node pools are updated and ready.
+	NodesUpToDateCondition apiv1beta1.ConditionType = "NodesUpToDate"
+)
+
+const (
+	// AppsNotDeployedReason is the reason of the AppsReadyCondition while
+	// apps are not deployed yet.
+	AppsNotDeployedReason = "AppsNotDeployed"
+	// CertificatesNotIssuedReason is the reason of the
+	// CertificatesReadyCondition while certificates are not issued yet.
+	CertificatesNotIssuedReason = "CertificatesNotIssued"
+	// ClusterValuesNotFoundReason is the reason of the
+	// ClusterValuesReadyCondition while the cluster values do not exist yet.
+	ClusterValuesNotFoundReason = "ClusterValuesNotFound"
+	// NodesUpgradingReason is the reason of the NodesUpToDateCondition while
+	// nodes of node pools are being replaced or are not ready.
+	NodesUpgradingReason = "NodesUpgrading"
+)
+
+// ReadyConditions are the conditions summarized in the Ready condition of the
+// Cluster CR.
+var ReadyConditions = []apiv1beta1.ConditionType{
+	CertificatesReadyCondition,
+	ClusterValuesReadyCondition,
+	KubeconfigReadyCondition,
+	AppsReadyCondition,
+	NodesUpToDateCondition,
+}
+
+const (
+	// CreationStuckCondition is set on the Cluster CR while the creation of
+	// the tenant cluster takes longer than the configured threshold.
+	CreationStuckCondition apiv1beta1.ConditionType = "CreationStuck"
+	// UpgradeStuckCondition is set on the Cluster CR while the upgrade of the
+	// tenant cluster takes longer than the configured threshold.
+	UpgradeStuckCondition apiv1beta1.ConditionType = "UpgradeStuck"
+)
+
+const (
+	// CreationTimeoutReason is the reason of the CreationStuckCondition.
+	CreationTimeoutReason = "CreationTimeout"
+	// UpgradeTimeoutReason is the reason of the UpgradeStuckCondition.
+	UpgradeTimeoutReason = "UpgradeTimeout"
+)
+
+const (
+	// AWSOperatorRoleARNValidCondition is set on the Cluster CR of AWS
+	// clusters once the aws-operator role ARN of the credential Secret is
+	// parsed successfully.
+	AWSOperatorRoleARNValidCondition apiv1beta1.ConditionType = "AWSOperatorRoleARNValid"
+)
+
+const (
+	// InvalidARNReason is the reason of the AWSOperatorRoleARNValidCondition
+	// while the ARN is malformed.
+	InvalidARNReason = "InvalidARN"
+)
+
+const (
+	// ClusterAnnotationsValidCondition is set on the Cluster CR to report
+	// whether the annotations configuring the cluster values can be applied.
+	// Invalid annotations are replaced by their defaults in the cluster
+	// values.
+	ClusterAnnotationsValidCondition apiv1beta1.ConditionType = "ClusterAnnotationsValid"
+)
+
+const (
+	// InvalidAnnotationReason is the reason of the
+	// ClusterAnnotationsValidCondition while an annotation is invalid.
+	InvalidAnnotationReason = "InvalidAnnotation"
+)
+
+const (
+	// DeletingCondition is set on the Cluster CR as soon as its deletion
+	// started and is kept until its finalizers are released.
+	DeletingCondition apiv1beta1.ConditionType = "Deleting"
+)
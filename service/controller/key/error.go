@@ -2,6 +2,24 @@ package key
 
 import "github.com/giantswarm/microerror"
 
+var invalidAnnotationError = &microerror.Error{
+	Kind: "invalidAnnotationError",
+}
+
+// IsInvalidAnnotation asserts invalidAnnotationError.
+func IsInvalidAnnotation(err error) bool {
+	return microerror.Cause(err) == invalidAnnotationError
+}
+
+var invalidARNError = &microerror.Error{
+	Kind: "invalidARNError",
+}
+
+// IsInvalidARN asserts invalidARNError.
+func IsInvalidARN(err error) bool {
+	return microerror.Cause(err) == invalidARNError
+}
+
 var invalidConfigError = &microerror.Error{
 	Kind: "invalidConfigError",
 }
@@ -29,6 +47,15 @@ func IsUnknownRelease(err error) bool {
 	return microerror.Cause(err) == unknownReleaseError
 }
 
+var unsupportedCiliumENIOptionsError = &microerror.Error{
+	Kind: "unsupportedCiliumENIOptionsError",
+}
+
+// IsUnsupportedCiliumENIOptions asserts unsupportedCiliumENIOptionsError.
+func IsUnsupportedCiliumENIOptions(err error) bool {
+	return microerror.Cause(err) == unsupportedCiliumENIOptionsError
+}
+
 var wrongTypeError = &microerror.Error{
 	Kind: "wrongTypeError",
 }
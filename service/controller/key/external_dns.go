@@ -0,0 +1,42 @@
+package key
+
+import (
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+const (
+	ExternalDNSZoneTypePrivate = "private"
+	ExternalDNSZoneTypePublic  = "public"
+)
+
+// ExternalDNSDomainFilters returns the additional domains external-dns in the
+// tenant cluster should manage besides the tenant cluster domain.
+func ExternalDNSDomainFilters(getter AnnotationsGetter) []string {
+	var filters []string
+	for _, f := range strings.Split(getter.GetAnnotations()[annotation.ExternalDNSDomainFilters], ",") {
+		f = strings.TrimSpace(f)
+		if f != "" {
+			filters = append(filters, f)
+		}
+	}
+
+	return filters
+}
+
+// ExternalDNSZoneType returns the type of hosted zones external-dns in the
+// tenant cluster is restricted to. An empty string means external-dns manages
+// public and private hosted zones.
+func ExternalDNSZoneType(getter AnnotationsGetter) (string, error) {
+	zoneType := getter.GetAnnotations()[annotation.ExternalDNSZoneType]
+
+	switch zoneType {
+	case "", ExternalDNSZoneTypePrivate, ExternalDNSZoneTypePublic:
+		return zoneType, nil
+	}
+
+	return "", microerror.Maskf(invalidAnnotationError, "annotation %#q must be %#q or %#q", annotation.ExternalDNSZoneType, ExternalDNSZoneTypePrivate, ExternalDNSZoneTypePublic)
+}
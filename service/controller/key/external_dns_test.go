@@ -0,0 +1,88 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+func Test_ExternalDNSDomainFilters(t *testing.T) {
+	testCases := []struct {
+		description string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			description: "no annotation",
+			expected:    nil,
+		},
+		{
+			description: "multiple domains",
+			annotations: map[string]string{
+				annotation.ExternalDNSDomainFilters: "internal.example.com, ,private.example.com",
+			},
+			expected: []string{"internal.example.com", "private.example.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual := ExternalDNSDomainFilters(&metav1.ObjectMeta{Annotations: tc.annotations})
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("domain filters %#v doesn't match expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_ExternalDNSZoneType(t *testing.T) {
+	testCases := []struct {
+		description  string
+		annotations  map[string]string
+		expected     string
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "no annotation",
+			expected:    "",
+		},
+		{
+			description: "private zones",
+			annotations: map[string]string{
+				annotation.ExternalDNSZoneType: "private",
+			},
+			expected: ExternalDNSZoneTypePrivate,
+		},
+		{
+			description: "error, unknown zone type",
+			annotations: map[string]string{
+				annotation.ExternalDNSZoneType: "internal",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := ExternalDNSZoneType(&metav1.ObjectMeta{Annotations: tc.annotations})
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if actual != tc.expected {
+				t.Fatalf("zone type %#q doesn't match expected %#q", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package key
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+const (
+	IngressLoadBalancerSchemeExternal = "internet-facing"
+	IngressLoadBalancerSchemeInternal = "internal"
+
+	IngressLoadBalancerTypeELB = "elb"
+	IngressLoadBalancerTypeNLB = "nlb"
+)
+
+// IngressLoadBalancer describes the load balancer of the ingress controller
+// in the tenant cluster.
+type IngressLoadBalancer struct {
+	ProxyProtocol bool
+	Scheme        string
+	SourceRanges  []string
+	// Type is only set for AWS, where it is either elb or nlb.
+	Type string
+}
+
+// DefaultIngressLoadBalancer returns the ingress controller load balancer of
+// clusters without annotations. AWS clusters get an internet-facing ELB using
+// the proxy protocol, all other providers an internet-facing load balancer
+// without proxy protocol.
+func DefaultIngressLoadBalancer(provider string) IngressLoadBalancer {
+	lb := IngressLoadBalancer{
+		Scheme: IngressLoadBalancerSchemeExternal,
+	}
+
+	if IsAWS(provider) {
+		lb.Type = IngressLoadBalancerTypeELB
+		lb.ProxyProtocol = true
+	}
+
+	return lb
+}
+
+// IngressLoadBalancerFromCluster returns the ingress controller load balancer
+// configured via the annotations of the given Cluster CR. Annotations which
+// are not set keep the values of DefaultIngressLoadBalancer.
+func IngressLoadBalancerFromCluster(getter AnnotationsGetter, provider string) (IngressLoadBalancer, error) {
+	annotations := getter.GetAnnotations()
+
+	lb := DefaultIngressLoadBalancer(provider)
+
+	if v, ok := annotations[annotation.IngressLoadBalancerScheme]; ok {
+		if v != IngressLoadBalancerSchemeExternal && v != IngressLoadBalancerSchemeInternal {
+			return IngressLoadBalancer{}, microerror.Maskf(invalidAnnotationError, "annotation %#q must be %#q or %#q", annotation.IngressLoadBalancerScheme, IngressLoadBalancerSchemeExternal, IngressLoadBalancerSchemeInternal)
+		}
+		lb.Scheme = v
+	}
+
+	if v, ok := annotations[annotation.IngressLoadBalancerType]; ok {
+		if !IsAWS(provider) {
+			return IngressLoadBalancer{}, microerror.Maskf(invalidAnnotationError, "annotation %#q is only supported on AWS", annotation.IngressLoadBalancerType)
+		}
+		if v != IngressLoadBalancerTypeELB && v != IngressLoadBalancerTypeNLB {
+			return IngressLoadBalancer{}, microerror.Maskf(invalidAnnotationError, "annotation %#q must be %#q or %#q", annotation.IngressLoadBalancerType, IngressLoadBalancerTypeELB, IngressLoadBalancerTypeNLB)
+		}
+		lb.Type = v
+	}
+
+	// The proxy protocol is enabled by default for the AWS ELB only, since the
+	// in-tree cloud provider cannot enable it for NLBs.
+	lb.ProxyProtocol = lb.Type == IngressLoadBalancerTypeELB
+	if v, ok := annotations[annotation.IngressProxyProtocol]; ok {
+		proxyProtocol, err := strconv.ParseBool(v)
+		if err != nil {
+			return IngressLoadBalancer{}, microerror.Maskf(invalidAnnotationError, "annotation %#q must be a boolean", annotation.IngressProxyProtocol)
+		}
+		if proxyProtocol && lb.Type == IngressLoadBalancerTypeNLB {
+			return IngressLoadBalancer{}, microerror.Maskf(invalidAnnotationError, "annotation %#q cannot be enabled for %#q load balancers", annotation.IngressProxyProtocol, IngressLoadBalancerTypeNLB)
+		}
+		lb.ProxyProtocol = proxyProtocol
+	}
+
+	for _, r := range strings.Split(annotations[annotation.IngressAllowlist], ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+
+		_, _, err := net.ParseCIDR(r)
+		if err != nil {
+			return IngressLoadBalancer{}, microerror.Maskf(invalidAnnotationError, "annotation %#q contains invalid CIDR %#q", annotation.IngressAllowlist, r)
+		}
+
+		lb.SourceRanges = append(lb.SourceRanges, r)
+	}
+
+	return lb, nil
+}
+
+// ServiceAnnotations returns the annotations of the ingress controller
+// Service which make the cloud provider create the described load balancer.
+func (lb IngressLoadBalancer) ServiceAnnotations(provider string) map[string]string {
+	annotations := map[string]string{}
+
+	switch {
+	case IsAWS(provider):
+		if lb.Type == IngressLoadBalancerTypeNLB {
+			annotations["service.beta.kubernetes.io/aws-load-balancer-type"] = IngressLoadBalancerTypeNLB
+		}
+		if lb.ProxyProtocol {
+			annotations["service.beta.kubernetes.io/aws-load-balancer-proxy-protocol"] = "*"
+		}
+		if lb.Scheme == IngressLoadBalancerSchemeInternal {
+			annotations["service.beta.kubernetes.io/aws-load-balancer-internal"] = "true"
+		}
+	case IsAzure(provider):
+		if lb.Scheme == IngressLoadBalancerSchemeInternal {
+			annotations["service.beta.kubernetes.io/azure-load-balancer-internal"] = "true"
+		}
+	}
+
+	return annotations
+}
@@ -0,0 +1,126 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+)
+
+func Test_IngressLoadBalancerFromCluster(t *testing.T) {
+	testCases := []struct {
+		description         string
+		annotations         map[string]string
+		provider            string
+		expected            IngressLoadBalancer
+		expectedAnnotations map[string]string
+		errorMatcher        func(error) bool
+	}{
+		{
+			description: "aws defaults",
+			provider:    label.ProviderAWS,
+			expected: IngressLoadBalancer{
+				ProxyProtocol: true,
+				Scheme:        IngressLoadBalancerSchemeExternal,
+				Type:          IngressLoadBalancerTypeELB,
+			},
+			expectedAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol": "*",
+			},
+		},
+		{
+			description: "azure defaults",
+			provider:    label.ProviderAzure,
+			expected: IngressLoadBalancer{
+				Scheme: IngressLoadBalancerSchemeExternal,
+			},
+			expectedAnnotations: map[string]string{},
+		},
+		{
+			description: "aws internal nlb with allowlist",
+			annotations: map[string]string{
+				annotation.IngressAllowlist:          "10.0.0.0/8, 192.168.0.0/16",
+				annotation.IngressLoadBalancerScheme: "internal",
+				annotation.IngressLoadBalancerType:   "nlb",
+			},
+			provider: label.ProviderAWS,
+			expected: IngressLoadBalancer{
+				Scheme:       IngressLoadBalancerSchemeInternal,
+				SourceRanges: []string{"10.0.0.0/8", "192.168.0.0/16"},
+				Type:         IngressLoadBalancerTypeNLB,
+			},
+			expectedAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+				"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb",
+			},
+		},
+		{
+			description: "aws elb without proxy protocol",
+			annotations: map[string]string{
+				annotation.IngressProxyProtocol: "false",
+			},
+			provider: label.ProviderAWS,
+			expected: IngressLoadBalancer{
+				Scheme: IngressLoadBalancerSchemeExternal,
+				Type:   IngressLoadBalancerTypeELB,
+			},
+			expectedAnnotations: map[string]string{},
+		},
+		{
+			description: "error, proxy protocol with nlb",
+			annotations: map[string]string{
+				annotation.IngressLoadBalancerType: "nlb",
+				annotation.IngressProxyProtocol:    "true",
+			},
+			provider:     label.ProviderAWS,
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, load balancer type on azure",
+			annotations: map[string]string{
+				annotation.IngressLoadBalancerType: "nlb",
+			},
+			provider:     label.ProviderAzure,
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, invalid allowlist",
+			annotations: map[string]string{
+				annotation.IngressAllowlist: "10.0.0.1",
+			},
+			provider:     label.ProviderAWS,
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := IngressLoadBalancerFromCluster(&metav1.ObjectMeta{Annotations: tc.annotations}, tc.provider)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("load balancer %#v doesn't match expected %#v", actual, tc.expected)
+			}
+			if a := actual.ServiceAnnotations(tc.provider); !reflect.DeepEqual(a, tc.expectedAnnotations) {
+				t.Fatalf("annotations %#v don't match expected %#v", a, tc.expectedAnnotations)
+			}
+		})
+	}
+}
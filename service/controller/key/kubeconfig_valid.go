@@ -0,0 +1,27 @@
+package key
+
+import (
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// KubeconfigValidCondition is set on the Cluster CR to report whether the
+	// generated kubeconfigs can be used to talk to the tenant API. It carries
+	// the server version of the tenant API if true and the last error if
+	// false.
+	KubeconfigValidCondition apiv1beta1.ConditionType = "KubeconfigValid"
+
+	// KubeconfigNotFoundReason is the reason of the KubeconfigValidCondition
+	// while a kubeconfig Secret was not generated yet.
+	KubeconfigNotFoundReason = "KubeconfigNotFound"
+	// KubeconfigInvalidReason is the reason of the KubeconfigValidCondition
+	// when a kubeconfig Secret cannot be loaded.
+	KubeconfigInvalidReason = "KubeconfigInvalid"
+	// TenantAPIUnreachableReason is the reason of the KubeconfigValidCondition
+	// when the tenant API version cannot be fetched using a kubeconfig.
+	TenantAPIUnreachableReason = "TenantAPIUnreachable"
+	// NoPublicVariantReason is the reason of the KubeconfigValidCondition
+	// when no kubeconfig variant uses the public endpoint, since only those
+	// are verified.
+	NoPublicVariantReason = "NoPublicVariant"
+)
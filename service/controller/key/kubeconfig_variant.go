@@ -0,0 +1,186 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// KubeConfigEndpointInternal makes kubeconfigs talk to the internal API
+	// endpoint of tenant clusters, which is meant for in-cluster consumers.
+	KubeConfigEndpointInternal = "internal"
+	// KubeConfigEndpointPublic makes kubeconfigs talk to the public API
+	// endpoint of tenant clusters.
+	KubeConfigEndpointPublic = "public"
+)
+
+const (
+	// kubeConfigAppSecretName is the name of the kubeconfig Secret App CRs of
+	// tenant clusters reference, appended to the cluster ID.
+	kubeConfigAppSecretName = "kubeconfig"
+)
+
+// KubeConfigVariant describes one of the kubeconfig Secrets generated for
+// every tenant cluster.
+type KubeConfigVariant struct {
+	// Name identifies the variant. It must be a DNS-1123 label.
+	Name string `yaml:"name"`
+	// Endpoint is the API endpoint the kubeconfig talks to. One of internal,
+	// public. Defaults to public.
+	Endpoint string `yaml:"endpoint"`
+	// Certificate is the tenant cluster certificate embedded in the
+	// kubeconfig, e.g. app-operator-api. Mutually exclusive with
+	// Organizations.
+	Certificate string `yaml:"certificate"`
+	// Organizations makes the operator issue a dedicated certificate for the
+	// variant with the given organizations, e.g. a group bound to a read-only
+	// role in the tenant cluster. Mutually exclusive with Certificate.
+	Organizations []string `yaml:"organizations"`
+	// Secret configures the Secret the kubeconfig is written to.
+	Secret KubeConfigVariantSecret `yaml:"secret"`
+}
+
+// KubeConfigVariantSecret configures the Secret of a kubeconfig variant.
+type KubeConfigVariantSecret struct {
+	// Name is appended to the cluster ID to form the Secret name. Defaults to
+	// kubeconfig-<variant>.
+	Name string `yaml:"name"`
+	// Namespace is the namespace of the Secret. Defaults to the namespace of
+	// the tenant cluster.
+	Namespace string `yaml:"namespace"`
+}
+
+// DefaultKubeConfigVariants are the kubeconfig variants generated when none
+// are configured. The single admin variant is the kubeconfig Secret the
+// operator always generated for Flux and legacy Giant Swarm operators.
+var DefaultKubeConfigVariants = []KubeConfigVariant{
+	{
+		Name:        "admin",
+		Endpoint:    KubeConfigEndpointPublic,
+		Certificate: certs.AppOperatorAPICert.String(),
+		Secret: KubeConfigVariantSecret{
+			Name: kubeConfigAppSecretName,
+		},
+	},
+}
+
+// ParseKubeConfigVariants parses and validates the YAML list of kubeconfig
+// variants configured for the installation. Defaults are filled in so that
+// consumers do not need to care about them.
+func ParseKubeConfigVariants(raw string) ([]KubeConfigVariant, error) {
+	var variants []KubeConfigVariant
+	err := yaml.Unmarshal([]byte(raw), &variants)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "kubeconfig variants must be a YAML list: %s", err.Error())
+	}
+
+	if len(variants) == 0 {
+		return DefaultKubeConfigVariants, nil
+	}
+
+	names := map[string]bool{}
+	secrets := map[string]bool{}
+	for i := range variants {
+		v := &variants[i]
+
+		if errs := validation.IsDNS1123Label(v.Name); len(errs) > 0 {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant name %#q is invalid: %v", v.Name, errs)
+		}
+		if names[v.Name] {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant %#q is configured twice", v.Name)
+		}
+		names[v.Name] = true
+
+		if v.Endpoint == "" {
+			v.Endpoint = KubeConfigEndpointPublic
+		}
+		if v.Endpoint != KubeConfigEndpointInternal && v.Endpoint != KubeConfigEndpointPublic {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant %#q has unknown endpoint %#q", v.Name, v.Endpoint)
+		}
+
+		if v.Certificate == "" && len(v.Organizations) == 0 {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant %#q must define certificate or organizations", v.Name)
+		}
+		if v.Certificate != "" && len(v.Organizations) > 0 {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant %#q must not define both certificate and organizations", v.Name)
+		}
+
+		if v.Secret.Name == "" {
+			v.Secret.Name = fmt.Sprintf("kubeconfig-%s", v.Name)
+		}
+		if errs := validation.IsDNS1123Subdomain(v.Secret.Name); len(errs) > 0 {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant %#q has invalid secret name %#q: %v", v.Name, v.Secret.Name, errs)
+		}
+		if v.Secret.Namespace != "" {
+			if errs := validation.IsDNS1123Label(v.Secret.Namespace); len(errs) > 0 {
+				return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant %#q has invalid secret namespace %#q: %v", v.Name, v.Secret.Namespace, errs)
+			}
+		}
+
+		s := v.Secret.Namespace + "/" + v.Secret.Name
+		if secrets[s] {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig variant %#q uses the secret of another variant", v.Name)
+		}
+		secrets[s] = true
+	}
+
+	if _, ok := AppKubeConfigVariant(variants); !ok {
+		return nil, microerror.Maskf(invalidConfigError, "kubeconfig variants must contain a variant with certificate %#q writing secret %#q to the cluster namespace for App CRs", certs.AppOperatorAPICert, kubeConfigAppSecretName)
+	}
+
+	return variants, nil
+}
+
+// AppKubeConfigVariant returns the variant of the kubeconfig Secret App CRs of
+// tenant clusters reference. It embeds the app-operator certificate and is
+// written to the cluster namespace.
+func AppKubeConfigVariant(variants []KubeConfigVariant) (KubeConfigVariant, bool) {
+	for _, v := range variants {
+		if v.Certificate == certs.AppOperatorAPICert.String() && v.Secret.Name == kubeConfigAppSecretName && v.Secret.Namespace == "" {
+			return v, true
+		}
+	}
+
+	return KubeConfigVariant{}, false
+}
+
+// KubeConfigVariantCertificate returns the certificate embedded in kubeconfigs
+// of the given variant. Variants defining organizations get their own
+// certificate.
+func KubeConfigVariantCertificate(v KubeConfigVariant) string {
+	if v.Certificate != "" {
+		return v.Certificate
+	}
+
+	return fmt.Sprintf("kubeconfig-%s", v.Name)
+}
+
+// KubeConfigVariantEndpoint returns the API endpoint kubeconfigs of the given
+// variant talk to.
+func KubeConfigVariantEndpoint(getter LabelsGetter, base string, v KubeConfigVariant) string {
+	if v.Endpoint == KubeConfigEndpointInternal {
+		return KubeConfigInternalEndpoint(getter, base)
+	}
+
+	return KubeConfigEndpoint(getter, base)
+}
+
+// KubeConfigVariantSecretName returns the name of the kubeconfig Secret of the
+// given variant.
+func KubeConfigVariantSecretName(getter LabelsGetter, v KubeConfigVariant) string {
+	return fmt.Sprintf("%s-%s", ClusterID(getter), v.Secret.Name)
+}
+
+// KubeConfigVariantSecretNamespace returns the namespace of the kubeconfig
+// Secret of the given variant.
+func KubeConfigVariantSecretNamespace(getter LabelsGetter, v KubeConfigVariant) string {
+	if v.Secret.Namespace != "" {
+		return v.Secret.Namespace
+	}
+
+	return ClusterID(getter)
+}
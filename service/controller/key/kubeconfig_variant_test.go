@@ -0,0 +1,155 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ParseKubeConfigVariants(t *testing.T) {
+	testCases := []struct {
+		description  string
+		raw          string
+		expected     []KubeConfigVariant
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "empty config results in default variants",
+			raw:         "",
+			expected:    DefaultKubeConfigVariants,
+		},
+		{
+			description: "empty list results in default variants",
+			raw:         "[]",
+			expected:    DefaultKubeConfigVariants,
+		},
+		{
+			description: "defaults are filled in",
+			raw: `
+- name: admin
+  certificate: app-operator-api
+  secret:
+    name: kubeconfig
+- name: internal
+  endpoint: internal
+  certificate: app-operator-api
+- name: read-only
+  organizations:
+  - giantswarm:read-only
+  secret:
+    namespace: monitoring
+`,
+			expected: []KubeConfigVariant{
+				{
+					Name:        "admin",
+					Endpoint:    KubeConfigEndpointPublic,
+					Certificate: "app-operator-api",
+					Secret: KubeConfigVariantSecret{
+						Name: "kubeconfig",
+					},
+				},
+				{
+					Name:        "internal",
+					Endpoint:    KubeConfigEndpointInternal,
+					Certificate: "app-operator-api",
+					Secret: KubeConfigVariantSecret{
+						Name: "kubeconfig-internal",
+					},
+				},
+				{
+					Name:          "read-only",
+					Endpoint:      KubeConfigEndpointPublic,
+					Organizations: []string{"giantswarm:read-only"},
+					Secret: KubeConfigVariantSecret{
+						Name:      "kubeconfig-read-only",
+						Namespace: "monitoring",
+					},
+				},
+			},
+		},
+		{
+			description: "JSON as rendered by the helm chart",
+			raw:         `[{"certificate":"app-operator-api","name":"admin","secret":{"name":"kubeconfig"}}]`,
+			expected: []KubeConfigVariant{
+				{
+					Name:        "admin",
+					Endpoint:    KubeConfigEndpointPublic,
+					Certificate: "app-operator-api",
+					Secret: KubeConfigVariantSecret{
+						Name: "kubeconfig",
+					},
+				},
+			},
+		},
+		{
+			description:  "error, no variant for App CRs",
+			raw:          "[{name: read-only, organizations: [view], secret: {name: kubeconfig}}]",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, variant for App CRs in another namespace",
+			raw:          "[{name: admin, certificate: app-operator-api, secret: {name: kubeconfig, namespace: giantswarm}}]",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, no list",
+			raw:          "name: admin",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, missing name",
+			raw:          "- certificate: app-operator-api",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, duplicate name",
+			raw:          "[{name: a, certificate: app-operator-api}, {name: a, certificate: prometheus}]",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, unknown endpoint",
+			raw:          "[{name: a, endpoint: private, certificate: app-operator-api}]",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, neither certificate nor organizations",
+			raw:          "[{name: a}]",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, certificate and organizations",
+			raw:          "[{name: a, certificate: app-operator-api, organizations: [view]}]",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, same secret for two variants",
+			raw:          "[{name: a, certificate: app-operator-api, secret: {name: kubeconfig}}, {name: b, certificate: prometheus, secret: {name: kubeconfig}}]",
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			description:  "error, invalid secret namespace",
+			raw:          "[{name: a, certificate: app-operator-api, secret: {namespace: Monitoring}}]",
+			errorMatcher: IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := ParseKubeConfigVariants(tc.raw)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("variants %#v doesn't match expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
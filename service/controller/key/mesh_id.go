@@ -0,0 +1,53 @@
+package key
+
+import (
+	"strconv"
+
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+const (
+	// CiliumClusterMeshIDAllocatedCondition is set on the Cluster CR of
+	// clusters requiring a Cilium cluster mesh ID once it is allocated.
+	CiliumClusterMeshIDAllocatedCondition apiv1beta1.ConditionType = "CiliumClusterMeshIDAllocated"
+
+	// MeshIDConflictReason is the reason of the
+	// CiliumClusterMeshIDAllocatedCondition in case the ID of the cluster is
+	// recorded for another cluster.
+	MeshIDConflictReason = "MeshIDConflict"
+	// MeshIDsExhaustedReason is the reason of the
+	// CiliumClusterMeshIDAllocatedCondition in case all IDs of the configured
+	// range are allocated.
+	MeshIDsExhaustedReason = "MeshIDsExhausted"
+)
+
+// CiliumClusterMeshIDRequired returns true in case the given Cluster CR needs a
+// Cilium cluster mesh ID, which is the case for clusters opting in to cluster
+// mesh. Rendering an ID changes the Cilium datapath, so clusters are never
+// given one without asking for it. Clusters which already got an ID keep
+// requiring it.
+func CiliumClusterMeshIDRequired(cluster apiv1beta1.Cluster) bool {
+	if _, ok := CiliumClusterMeshID(&cluster); ok {
+		return true
+	}
+
+	return cluster.Annotations[annotation.CiliumClusterMeshEnabled] == "true"
+}
+
+// CiliumClusterMeshID returns the Cilium cluster mesh ID allocated to the
+// given Cluster CR and whether one is allocated at all.
+func CiliumClusterMeshID(getter AnnotationsGetter) (int, bool) {
+	v, ok := getter.GetAnnotations()[annotation.CiliumClusterMeshID]
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
@@ -1,11 +1,9 @@
 package key
 
-import "strings"
-
 func IsAWS(provider string) bool {
 	return provider == "aws"
 }
 
-func IsAWSChina(region string) bool {
-	return strings.HasPrefix(region, "cn-")
+func IsAzure(provider string) bool {
+	return provider == "azure"
 }
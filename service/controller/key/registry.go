@@ -0,0 +1,68 @@
+package key
+
+import (
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+// RegistryDomain returns the image registry domain apps of the given tenant
+// cluster pull from. The registry annotation of the Cluster CR takes
+// precedence over the installation wide default.
+func RegistryDomain(getter AnnotationsGetter, defaultDomain string) (string, error) {
+	domain, ok := getter.GetAnnotations()[annotation.RegistryDomain]
+	if !ok {
+		return defaultDomain, nil
+	}
+
+	err := validateRegistry(annotation.RegistryDomain, domain)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return domain, nil
+}
+
+// RegistryMirrors returns the image registry mirrors of the given tenant
+// cluster. The mirrors annotation of the Cluster CR takes precedence over the
+// installation wide default. Setting the annotation to an empty value disables
+// mirrors for the tenant cluster.
+func RegistryMirrors(getter AnnotationsGetter, defaultMirrors []string) ([]string, error) {
+	v, ok := getter.GetAnnotations()[annotation.RegistryMirrors]
+	if !ok {
+		return defaultMirrors, nil
+	}
+
+	mirrors := []string{}
+	for _, m := range strings.Split(v, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+
+		err := validateRegistry(annotation.RegistryMirrors, m)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		mirrors = append(mirrors, m)
+	}
+
+	return mirrors, nil
+}
+
+func validateRegistry(name, domain string) error {
+	if domain == "" {
+		return microerror.Maskf(invalidAnnotationError, "annotation %#q must not be empty", name)
+	}
+	if strings.Contains(domain, "://") {
+		return microerror.Maskf(invalidAnnotationError, "annotation %#q registry %#q must not contain a scheme", name, domain)
+	}
+	if strings.ContainsAny(domain, " \t\n") {
+		return microerror.Maskf(invalidAnnotationError, "annotation %#q registry %#q must not contain whitespace", name, domain)
+	}
+
+	return nil
+}
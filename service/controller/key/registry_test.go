@@ -0,0 +1,126 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+func Test_RegistryDomain(t *testing.T) {
+	testCases := []struct {
+		description  string
+		annotations  map[string]string
+		expected     string
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "default domain",
+			expected:    "gsoci.azurecr.io",
+		},
+		{
+			description: "domain from annotation",
+			annotations: map[string]string{
+				annotation.RegistryDomain: "registry.example.com",
+			},
+			expected: "registry.example.com",
+		},
+		{
+			description: "error, empty annotation",
+			annotations: map[string]string{
+				annotation.RegistryDomain: "",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, annotation with scheme",
+			annotations: map[string]string{
+				annotation.RegistryDomain: "https://registry.example.com",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tc.annotations}
+
+			actual, err := RegistryDomain(obj, "gsoci.azurecr.io")
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if actual != tc.expected {
+				t.Fatalf("domain %#q doesn't match expected %#q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_RegistryMirrors(t *testing.T) {
+	testCases := []struct {
+		description  string
+		annotations  map[string]string
+		expected     []string
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "default mirrors",
+			expected:    []string{"giantswarm.azurecr.io"},
+		},
+		{
+			description: "mirrors from annotation",
+			annotations: map[string]string{
+				annotation.RegistryMirrors: "mirror-1.example.com, mirror-2.example.com",
+			},
+			expected: []string{"mirror-1.example.com", "mirror-2.example.com"},
+		},
+		{
+			description: "empty annotation disables mirrors",
+			annotations: map[string]string{
+				annotation.RegistryMirrors: "",
+			},
+			expected: []string{},
+		},
+		{
+			description: "error, annotation with whitespace",
+			annotations: map[string]string{
+				annotation.RegistryMirrors: "mirror example.com",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tc.annotations}
+
+			actual, err := RegistryMirrors(obj, []string{"giantswarm.azurecr.io"})
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("mirrors %#v doesn't match expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -4,6 +4,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+type AnnotationsGetter interface {
+	GetAnnotations() map[string]string
+}
+
 type DeletionTimestampGetter interface {
 	GetDeletionTimestamp() *metav1.Time
 }
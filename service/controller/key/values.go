@@ -0,0 +1,152 @@
+package key
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+// deniedValuesPaths are the cluster values owned by the operator. They must
+// not be overridden via annotations since other components rely on them.
+var deniedValuesPaths = []string{
+	"aws",
+	"baseDomain",
+	"bootstrapMode",
+	"ciliumNetworkPolicy",
+	"cluster.calico",
+	"cluster.kubernetes",
+	"clusterCA",
+	"clusterDNSIP",
+	"clusterID",
+	"global.image",
+	"global.podSecurityStandards",
+	"managementCluster",
+	"proxy",
+}
+
+// ValuesOverride is a cluster value set via an annotation of the Cluster CR.
+type ValuesOverride struct {
+	// Annotation is the name of the annotation defining the override.
+	Annotation string
+	// Path is the dotted path of the value in the cluster values.
+	Path string
+	// Value is the parsed annotation value.
+	Value interface{}
+}
+
+// ValuesOverrides returns the cluster values overrides defined via annotations
+// of the given Cluster CR, sorted by path so that overrides of nested paths
+// are applied after their parents. Annotation values are parsed as YAML so
+// that numbers, booleans, lists and maps keep their type.
+func ValuesOverrides(getter AnnotationsGetter) ([]ValuesOverride, error) {
+	var overrides []ValuesOverride
+	for k, v := range getter.GetAnnotations() {
+		if !strings.HasPrefix(k, annotation.ValuesPrefix) {
+			continue
+		}
+
+		path := strings.TrimPrefix(k, annotation.ValuesPrefix)
+		for _, p := range strings.Split(path, ".") {
+			if p == "" {
+				return nil, microerror.Maskf(invalidAnnotationError, "annotation %#q has invalid values path %#q", k, path)
+			}
+		}
+
+		var value interface{}
+		err := yaml.Unmarshal([]byte(v), &value)
+		if err != nil {
+			return nil, microerror.Maskf(invalidAnnotationError, "annotation %#q has invalid value: %s", k, err.Error())
+		}
+
+		overrides = append(overrides, ValuesOverride{
+			Annotation: k,
+			Path:       path,
+			Value:      value,
+		})
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Path < overrides[j].Path
+	})
+
+	return overrides, nil
+}
+
+// IsDeniedValuesPath returns true in case the given dotted path is, or is
+// nested in, a cluster value owned by the operator.
+func IsDeniedValuesPath(path string) bool {
+	for _, d := range deniedValuesPaths {
+		if path == d || strings.HasPrefix(path, d+".") || strings.HasPrefix(d, path+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApplyValuesOverride merges the given override into the values at its path.
+// Maps are merged recursively, all other values are replaced. Intermediate
+// maps are created as needed.
+func ApplyValuesOverride(values map[string]interface{}, o ValuesOverride) error {
+	parts := strings.Split(o.Path, ".")
+
+	m := values
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := m[p]
+		if !ok {
+			n := map[string]interface{}{}
+			m[p] = n
+			m = n
+			continue
+		}
+
+		n, ok := next.(map[string]interface{})
+		if !ok {
+			return microerror.Maskf(invalidAnnotationError, "annotation %#q overrides path %#q but %#q is not a map", o.Annotation, o.Path, p)
+		}
+		m = n
+	}
+
+	last := parts[len(parts)-1]
+	m[last] = mergeValues(m[last], o.Value)
+
+	return nil
+}
+
+func mergeValues(dst, src interface{}) interface{} {
+	d, ok := dst.(map[string]interface{})
+	if !ok {
+		return src
+	}
+	s, ok := src.(map[string]interface{})
+	if !ok {
+		return src
+	}
+
+	for k, v := range s {
+		d[k] = mergeValues(d[k], v)
+	}
+
+	return d
+}
+
+// LookupValuesPath returns the value at the given dotted path of the values.
+func LookupValuesPath(values map[string]interface{}, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+
+	m := values
+	for _, p := range parts[:len(parts)-1] {
+		n, ok := m[p].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = n
+	}
+
+	v, ok := m[parts[len(parts)-1]]
+	return v, ok
+}
@@ -0,0 +1,165 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ValuesOverrides(t *testing.T) {
+	testCases := []struct {
+		description  string
+		annotations  map[string]string
+		values       map[string]interface{}
+		expected     map[string]interface{}
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "typed values are merged at their path",
+			annotations: map[string]string{
+				"values.cluster-operator.giantswarm.io/global.podSecurityStandards.enforced": "false",
+				"values.cluster-operator.giantswarm.io/bootstrapMode":                        "{replicas: 2}",
+				"values.cluster-operator.giantswarm.io/team.name":                            "phoenix",
+				"giantswarm.io/unrelated":                                                    "true",
+			},
+			values: map[string]interface{}{
+				"bootstrapMode": map[string]interface{}{
+					"enabled": true,
+				},
+				"global": map[string]interface{}{
+					"podSecurityStandards": map[string]interface{}{
+						"enforced": true,
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"bootstrapMode": map[string]interface{}{
+					"enabled":  true,
+					"replicas": 2,
+				},
+				"global": map[string]interface{}{
+					"podSecurityStandards": map[string]interface{}{
+						"enforced": false,
+					},
+				},
+				"team": map[string]interface{}{
+					"name": "phoenix",
+				},
+			},
+		},
+		{
+			description: "error, empty path segment",
+			annotations: map[string]string{
+				"values.cluster-operator.giantswarm.io/global..enforced": "false",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+		{
+			description: "error, path through non-map value",
+			annotations: map[string]string{
+				"values.cluster-operator.giantswarm.io/team.name": "phoenix",
+			},
+			values: map[string]interface{}{
+				"team": "phoenix",
+			},
+			errorMatcher: IsInvalidAnnotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			overrides, err := ValuesOverrides(&metav1.ObjectMeta{Annotations: tc.annotations})
+			if err == nil {
+				for _, o := range overrides {
+					err = ApplyValuesOverride(tc.values, o)
+					if err != nil {
+						break
+					}
+				}
+			}
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(tc.values, tc.expected) {
+				t.Fatalf("values %#v don't match expected %#v", tc.values, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_IsDeniedValuesPath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "clusterID", expected: true},
+		{path: "baseDomain", expected: true},
+		{path: "cluster.kubernetes.DNS.IP", expected: true},
+		{path: "cluster", expected: true},
+		{path: "clusterIDs", expected: false},
+		{path: "cluster.foo", expected: false},
+		{path: "global.image.registry", expected: true},
+		{path: "global.image", expected: true},
+		{path: "global.podSecurityStandards.enforced", expected: true},
+		{path: "global", expected: true},
+		{path: "global.debug", expected: false},
+		{path: "bootstrapMode.enabled", expected: true},
+		{path: "ciliumNetworkPolicy.enabled", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			if actual := IsDeniedValuesPath(tc.path); actual != tc.expected {
+				t.Fatalf("IsDeniedValuesPath(%#q) == %t, want %t", tc.path, actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_LookupValuesPath(t *testing.T) {
+	values := map[string]interface{}{
+		"global": map[string]interface{}{
+			"podSecurityStandards": map[string]interface{}{
+				"enforced": false,
+			},
+		},
+		"clusterID": "8y5ck",
+	}
+
+	testCases := []struct {
+		path     string
+		expected interface{}
+		found    bool
+	}{
+		{path: "global.podSecurityStandards.enforced", expected: false, found: true},
+		{path: "clusterID", expected: "8y5ck", found: true},
+		{path: "global.image.registry", found: false},
+		{path: "clusterID.foo", found: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			actual, found := LookupValuesPath(values, tc.path)
+			if found != tc.found {
+				t.Fatalf("found == %t, want %t", found, tc.found)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("value == %#v, want %#v", actual, tc.expected)
+			}
+		})
+	}
+}
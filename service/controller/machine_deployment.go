@@ -122,10 +122,13 @@ func newMachineDeploymentResources(config MachineDeploymentConfig) ([]resource.I
 	var machineDeploymentStatusResource resource.Interface
 	{
 		c := machinedeploymentstatus.Config{
-			Event:     config.Event,
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
-			NodeCount: config.NodeCount,
+			Event:          config.Event,
+			K8sClient:      config.K8sClient,
+			Logger:         config.Logger,
+			NodeCount:      config.NodeCount,
+			ReleaseVersion: config.ReleaseVersion,
+
+			Provider: config.Provider,
 		}
 
 		machineDeploymentStatusResource, err = machinedeploymentstatus.New(c)
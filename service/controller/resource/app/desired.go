@@ -188,6 +188,15 @@ func (r *Resource) newApp(appOperatorVersion string, cr apiv1beta1.Cluster, appS
 				Namespace: key.ClusterID(&cr),
 			},
 		}
+
+		// Sensitive cluster values are kept in a Secret next to the cluster
+		// config map.
+		if appSpec.ConfigMapName == "" {
+			config.Secret = g8sv1alpha1.AppSpecConfigSecret{
+				Name:      key.ClusterSecretValuesName(&cr),
+				Namespace: key.ClusterID(&cr),
+			}
+		}
 	}
 
 	var kubeConfig g8sv1alpha1.AppSpecKubeConfig
@@ -199,11 +208,11 @@ func (r *Resource) newApp(appOperatorVersion string, cr apiv1beta1.Cluster, appS
 	} else {
 		kubeConfig = g8sv1alpha1.AppSpecKubeConfig{
 			Context: g8sv1alpha1.AppSpecKubeConfigContext{
-				Name: key.KubeConfigSecretName(&cr),
+				Name: key.KubeConfigVariantSecretName(&cr, r.kubeConfigVariant),
 			},
 			Secret: g8sv1alpha1.AppSpecKubeConfigSecret{
-				Name:      key.KubeConfigSecretName(&cr),
-				Namespace: key.ClusterID(&cr),
+				Name:      key.KubeConfigVariantSecretName(&cr, r.kubeConfigVariant),
+				Namespace: key.KubeConfigVariantSecretNamespace(&cr, r.kubeConfigVariant),
 			},
 		}
 	}
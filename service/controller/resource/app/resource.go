@@ -7,6 +7,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
 )
 
@@ -23,6 +24,7 @@ type Config struct {
 	ReleaseVersion releaseversion.Interface
 
 	KiamWatchDogEnabled  bool
+	KubeConfigVariants   []key.KubeConfigVariant
 	Provider             string
 	RawAppDefaultConfig  string
 	RawAppOverrideConfig string
@@ -37,6 +39,7 @@ type Resource struct {
 
 	defaultConfig       defaultConfig
 	kiamWatchDogEnabled bool
+	kubeConfigVariant   key.KubeConfigVariant
 	overrideConfig      overrideConfig
 	provider            string
 }
@@ -70,6 +73,10 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.ReleaseVersion must not be empty", config)
 	}
 
+	kubeConfigVariant, ok := key.AppKubeConfigVariant(config.KubeConfigVariants)
+	if !ok {
+		return nil, microerror.Maskf(invalidConfigError, "%T.KubeConfigVariants must contain the variant for App CRs", config)
+	}
 	if config.Provider == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
 	}
@@ -100,6 +107,7 @@ func New(config Config) (*Resource, error) {
 
 		defaultConfig:       defaultConfig,
 		kiamWatchDogEnabled: config.KiamWatchDogEnabled,
+		kubeConfigVariant:   kubeConfigVariant,
 		overrideConfig:      overrideConfig,
 		provider:            config.Provider,
 	}
@@ -0,0 +1,105 @@
+package certbackend
+
+import (
+	"context"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if b := key.CertBackend(&cr); b != "" {
+		if !key.IsValidCertBackend(b) {
+			return microerror.Maskf(invalidCertBackendError, "annotation %#q must be one of %#q or %#q, got %#q", annotation.CertBackend, key.CertBackendCertConfig, key.CertBackendCertManager, b)
+		}
+
+		r.logger.Debugf(ctx, "certificate backend %#q already recorded", b)
+		return nil
+	}
+
+	var backend string
+	{
+		var certConfigs corev1alpha1.CertConfigList
+		err = r.ctrlClient.List(
+			ctx,
+			&certConfigs,
+			client.InNamespace(cr.Namespace),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr)},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		// Clusters created before the backend was recorded already have
+		// certificates issued by cert-operator. They stay on it no matter the
+		// default backend.
+		if len(certConfigs.Items) > 0 {
+			backend = key.CertBackendCertConfig
+		} else {
+			componentVersions, err := r.releaseVersion.ComponentVersion(ctx, &cr)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			backend = key.InitialCertBackend(r.certBackend, componentVersions[releaseversion.CertOperator].Version)
+		}
+	}
+
+	r.logger.Debugf(ctx, "recording certificate backend %#q", backend)
+
+	err = r.recordBackend(ctx, cr, backend)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "recorded certificate backend %#q", backend)
+
+	// The certificate resources act based on the annotation of the reconciled
+	// Cluster CR, so we start over once it is set.
+	r.logger.Debugf(ctx, "canceling reconciliation")
+	reconciliationcanceledcontext.SetCanceled(ctx)
+
+	return nil
+}
+
+func (r *Resource) recordBackend(ctx context.Context, cl apiv1beta1.Cluster, backend string) error {
+	// Fetch the latest version of the Cluster CR since the one we reconcile
+	// may already be outdated.
+	var cr apiv1beta1.Cluster
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if key.CertBackend(&cr) != "" {
+		return nil
+	}
+
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+	cr.Annotations[annotation.CertBackend] = backend
+
+	err := r.ctrlClient.Update(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package certbackend
+
+import (
+	"context"
+	"testing"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+type fakeReleaseVersion struct {
+	certOperatorVersion string
+}
+
+func (f fakeReleaseVersion) Apps(ctx context.Context, obj interface{}) (map[string]releaseversion.ReleaseApp, error) {
+	return nil, nil
+}
+
+func (f fakeReleaseVersion) ComponentVersion(ctx context.Context, obj interface{}) (map[string]releaseversion.ReleaseComponent, error) {
+	return map[string]releaseversion.ReleaseComponent{
+		releaseversion.CertOperator: {Version: f.certOperatorVersion},
+	}, nil
+}
+
+func Test_CertBackend_EnsureCreated(t *testing.T) {
+	testCases := []struct {
+		name                string
+		annotation          string
+		certConfig          bool
+		certOperatorVersion string
+		defaultBackend      string
+
+		expectBackend  string
+		expectCanceled bool
+		errorMatcher   func(error) bool
+	}{
+		{
+			name:                "case 0: recorded backend is kept",
+			annotation:          key.CertBackendCertConfig,
+			certOperatorVersion: "",
+			defaultBackend:      key.CertBackendCertManager,
+			expectBackend:       key.CertBackendCertConfig,
+		},
+		{
+			name:                "case 1: existing cluster with CertConfig stays on certconfig",
+			certConfig:          true,
+			certOperatorVersion: "3.0.0",
+			defaultBackend:      key.CertBackendCertManager,
+			expectBackend:       key.CertBackendCertConfig,
+			expectCanceled:      true,
+		},
+		{
+			name:                "case 2: new cluster gets default backend",
+			certOperatorVersion: "3.0.0",
+			defaultBackend:      key.CertBackendCertManager,
+			expectBackend:       key.CertBackendCertManager,
+			expectCanceled:      true,
+		},
+		{
+			name:                "case 3: new cluster without cert-operator gets cert-manager",
+			certOperatorVersion: "",
+			defaultBackend:      key.CertBackendCertConfig,
+			expectBackend:       key.CertBackendCertManager,
+			expectCanceled:      true,
+		},
+		{
+			name:           "case 4: invalid recorded backend returns error",
+			annotation:     "vault",
+			defaultBackend: key.CertBackendCertConfig,
+			expectBackend:  "vault",
+			errorMatcher:   IsInvalidCertBackend,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := reconciliationcanceledcontext.NewContext(context.Background(), make(chan struct{}))
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck",
+					Namespace: "org-giantswarm",
+					Labels: map[string]string{
+						label.Cluster: "8y5ck",
+					},
+				},
+			}
+			if tc.annotation != "" {
+				cluster.Annotations = map[string]string{
+					annotation.CertBackend: tc.annotation,
+				}
+			}
+			err := ctrlClient.Create(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.certConfig {
+				err = ctrlClient.Create(ctx, &corev1alpha1.CertConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "8y5ck-api",
+						Namespace: "org-giantswarm",
+						Labels: map[string]string{
+							label.Cluster: "8y5ck",
+						},
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r, err := New(Config{
+				CtrlClient:     ctrlClient,
+				Logger:         microloggertest.New(),
+				ReleaseVersion: fakeReleaseVersion{certOperatorVersion: tc.certOperatorVersion},
+
+				CertBackend: tc.defaultBackend,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = r.EnsureCreated(ctx, cluster)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if canceled := reconciliationcanceledcontext.IsCanceled(ctx); canceled != tc.expectCanceled {
+				t.Fatalf("canceled == %t, want %t", canceled, tc.expectCanceled)
+			}
+
+			var updated apiv1beta1.Cluster
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if b := key.CertBackend(&updated); b != tc.expectBackend {
+				t.Fatalf("backend == %#q, want %#q", b, tc.expectBackend)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package certbackend
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidCertBackendError = &microerror.Error{
+	Kind: "invalidCertBackendError",
+}
+
+// IsInvalidCertBackend asserts invalidCertBackendError.
+func IsInvalidCertBackend(err error) bool {
+	return microerror.Cause(err) == invalidCertBackendError
+}
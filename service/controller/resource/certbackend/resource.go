@@ -0,0 +1,65 @@
+package certbackend
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
+)
+
+const (
+	Name = "certbackend"
+)
+
+type Config struct {
+	CtrlClient     ctrlClient.Client
+	Logger         micrologger.Logger
+	ReleaseVersion releaseversion.Interface
+
+	CertBackend string
+}
+
+// Resource records the certificate backend of tenant clusters in the
+// cert-backend annotation of their Cluster CR. The certconfig, certmanager and
+// certrotation resources only act based on the recorded backend, so that
+// changing the installation wide default or upgrading to a release without
+// cert-operator does not move existing clusters to another backend and CA.
+type Resource struct {
+	ctrlClient     ctrlClient.Client
+	logger         micrologger.Logger
+	releaseVersion releaseversion.Interface
+
+	certBackend string
+}
+
+func New(config Config) (*Resource, error) {
+	if config.CtrlClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.ReleaseVersion == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ReleaseVersion must not be empty", config)
+	}
+
+	if !key.IsValidCertBackend(config.CertBackend) {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CertBackend must be one of %#q or %#q", config, key.CertBackendCertConfig, key.CertBackendCertManager)
+	}
+
+	r := &Resource{
+		ctrlClient:     config.CtrlClient,
+		logger:         config.Logger,
+		releaseVersion: config.ReleaseVersion,
+
+		certBackend: config.CertBackend,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
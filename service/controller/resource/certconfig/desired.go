@@ -5,11 +5,15 @@ import (
 	"fmt"
 
 	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
-	"github.com/giantswarm/certs/v4/pkg/certs"
 	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
 	"github.com/giantswarm/cluster-operator/v5/pkg/label"
 	"github.com/giantswarm/cluster-operator/v5/pkg/project"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
@@ -38,23 +42,18 @@ func (r *Resource) getDesiredState(ctx context.Context, obj interface{}) (interf
 		return nil, nil
 	}
 
-	// We need to determine if we want to generate certificates for a Tenant
-	// Cluster with a HA Master setup.
-	var haMasterEnabled bool
-	{
-		haMasterEnabled, err = r.haMaster.Enabled(ctx, key.ClusterID(&cr))
-		if hamaster.IsNotFound(err) {
-			r.logger.Debugf(ctx, "not computing desired state", "reason", "control plane CR not available yet")
-			r.logger.Debugf(ctx, "canceling resource")
-			return nil, nil
-		} else if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-	bd, err := r.baseDomain.BaseDomain(ctx, &cr)
-	if err != nil {
-		return nil, microerror.Mask(err)
+	switch key.CertBackend(&cr) {
+	case key.CertBackendCertConfig:
+		// CertConfig CRs are computed below.
+	case key.CertBackendCertManager:
+		return r.desiredStateForCertManager(ctx, cr)
+	default:
+		r.logger.Debugf(ctx, "not computing desired state", "reason", "certificate backend not recorded yet")
+		r.logger.Debugf(ctx, "canceling resource")
+		resourcecanceledcontext.SetCanceled(ctx)
+		return nil, nil
 	}
+
 	componentVersions, err := r.releaseVersion.ComponentVersion(ctx, &cr)
 	if err != nil {
 		return nil, microerror.Mask(err)
@@ -66,44 +65,77 @@ func (r *Resource) getDesiredState(ctx context.Context, obj interface{}) (interf
 		return nil, microerror.Maskf(notFoundError, "%#q component version not found", releaseversion.CertOperator)
 	}
 
+	specs, err := r.certSpec.Specs(ctx, cr)
+	if hamaster.IsNotFound(err) {
+		r.logger.Debugf(ctx, "not computing desired state", "reason", "control plane CR not available yet")
+		r.logger.Debugf(ctx, "canceling resource")
+		resourcecanceledcontext.SetCanceled(ctx)
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
 	var certConfigs []*corev1alpha1.CertConfig
-	{
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForAPI(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForAppOperator(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForAWSOperator(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForCalico(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForClusterOperator(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForNodeOperator(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForPrometheus(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForPrometheusEtcdClient(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForServiceAccount(ctx, bd, cr)))
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForWorker(ctx, bd, cr)))
+	for _, spec := range specs {
+		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, spec))
+	}
 
-		if haMasterEnabled {
-			certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForEtcd1(ctx, bd, cr)))
-			certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForEtcd2(ctx, bd, cr)))
-			certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForEtcd3(ctx, bd, cr)))
-		} else {
-			certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForEtcd(ctx, bd, cr)))
-		}
+	return certConfigs, nil
+}
+
+// desiredStateForCertManager returns the desired CertConfigs of Tenant Clusters
+// using the cert-manager backend. Clusters moved from the certconfig backend
+// keep their CertConfig CRs until cert-manager issued all certificates with
+// the reused CA, so that the certificates of cert-operator stay in place in
+// the meantime. Afterwards the desired state is empty, which removes them.
+func (r *Resource) desiredStateForCertManager(ctx context.Context, cr apiv1beta1.Cluster) (interface{}, error) {
+	specs, err := r.certSpec.Specs(ctx, cr)
+	if hamaster.IsNotFound(err) {
+		r.logger.Debugf(ctx, "not computing desired state", "reason", "control plane CR not available yet")
+		r.logger.Debugf(ctx, "canceling resource")
+		resourcecanceledcontext.SetCanceled(ctx)
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
 
-		if r.provider == label.ProviderKVM {
-			certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForFlanneldEtcdClient(ctx, bd, cr)))
+	for _, s := range specs {
+		var secret corev1.Secret
+		err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: key.CertManagerCertsSecretName(&cr, s.ClusterComponent), Namespace: cr.Namespace}, &secret)
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "not computing desired state", "reason", fmt.Sprintf("waiting for cert-manager to issue certificate %#q", s.ClusterComponent))
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+			return nil, nil
+		} else if err != nil {
+			return nil, microerror.Mask(err)
 		}
 	}
 
-	return certConfigs, nil
+	r.logger.Debugf(ctx, "not computing desired state", "reason", "certificates are issued by cert-manager")
+
+	return []*corev1alpha1.CertConfig{}, nil
 }
 
 func newCertConfig(certOperatorVersion string, cr apiv1beta1.Cluster, cert corev1alpha1.CertConfigSpecCert) *corev1alpha1.CertConfig {
+	// The rotation generation is bumped by the certrotation resource once a
+	// certificate rotation of the tenant cluster was started.
+	var annotations map[string]string
+	if g := key.CertificatesRotationGeneration(&cr); g != "" {
+		annotations = map[string]string{
+			annotation.RotationGeneration: g,
+		}
+	}
+
 	return &corev1alpha1.CertConfig{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "CertConfig",
 			APIVersion: "core.giantswarm.io",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      key.CertConfigName(&cr, cert.ClusterComponent),
-			Namespace: cr.Namespace,
+			Name:        key.CertConfigName(&cr, cert.ClusterComponent),
+			Namespace:   cr.Namespace,
+			Annotations: annotations,
 			Labels: map[string]string{
 				label.Certificate:         cert.ClusterComponent,
 				label.CertOperatorVersion: certOperatorVersion,
@@ -117,201 +149,3 @@ func newCertConfig(certOperatorVersion string, cr apiv1beta1.Cluster, cert corev
 		},
 	}
 }
-
-func (r *Resource) newSpecForAPI(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	defaultAltNames := key.CertDefaultAltNames(r.clusterDomain)
-	desiredAltNames := append(defaultAltNames,
-		fmt.Sprintf("master.%s", key.ClusterID(&cr)),
-		fmt.Sprintf("internal-api.%s.k8s.%s", key.ClusterID(&cr), bd),
-	)
-
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		AltNames:         desiredAltNames,
-		ClusterComponent: certs.APICert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("api.%s.k8s.%s", key.ClusterID(&cr), bd),
-		IPSANs:           []string{r.apiIP, key.LocalhostIP},
-		Organizations:    []string{"system:masters"},
-		TTL:              r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForAppOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.AppOperatorAPICert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("app-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
-		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
-		//
-		//     https://github.com/giantswarm/giantswarm/issues/6822
-		//
-		Organizations: []string{"system:masters"},
-		TTL:           r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForAWSOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.AWSOperatorAPICert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("aws-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
-		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
-		//
-		//     https://github.com/giantswarm/giantswarm/issues/6822
-		//
-		Organizations: []string{"system:masters"},
-		TTL:           r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForCalico(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.CalicoEtcdClientCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("calico.%s.k8s.%s", key.ClusterID(&cr), bd),
-		TTL:              r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForClusterOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.ClusterOperatorAPICert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("cluster-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
-		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
-		//
-		//     https://github.com/giantswarm/giantswarm/issues/6822
-		//
-		Organizations: []string{"system:masters"},
-		TTL:           r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForEtcd(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.EtcdCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("etcd.%s.k8s.%s", key.ClusterID(&cr), bd),
-		IPSANs:           []string{"127.0.0.1"},
-		TTL:              r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForEtcd1(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.Etcd1Cert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("etcd.%s.k8s.%s", key.ClusterID(&cr), bd),
-		AltNames: []string{
-			fmt.Sprintf("etcd1.%s.k8s.%s", key.ClusterID(&cr), bd),
-		},
-		IPSANs: []string{"127.0.0.1"},
-		TTL:    r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForEtcd2(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.Etcd2Cert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("etcd.%s.k8s.%s", key.ClusterID(&cr), bd),
-		AltNames: []string{
-			fmt.Sprintf("etcd2.%s.k8s.%s", key.ClusterID(&cr), bd),
-		},
-		IPSANs: []string{"127.0.0.1"},
-		TTL:    r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForEtcd3(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.Etcd3Cert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("etcd.%s.k8s.%s", key.ClusterID(&cr), bd),
-		AltNames: []string{
-			fmt.Sprintf("etcd3.%s.k8s.%s", key.ClusterID(&cr), bd),
-		},
-		IPSANs: []string{"127.0.0.1"},
-		TTL:    r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForFlanneldEtcdClient(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.FlanneldEtcdClientCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("flanneld-etcd-client.%s.k8s.%s", key.ClusterID(&cr), bd),
-		TTL:              r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForNodeOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.NodeOperatorCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("node-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
-		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
-		//
-		//     https://github.com/giantswarm/giantswarm/issues/6822
-		//
-		Organizations: []string{"system:masters"},
-		TTL:           r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForPrometheus(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.PrometheusCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("prometheus.%s.k8s.%s", key.ClusterID(&cr), bd),
-		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
-		//
-		//     https://github.com/giantswarm/giantswarm/issues/6822
-		//
-		Organizations: []string{"system:masters"},
-		TTL:           r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForPrometheusEtcdClient(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.PrometheusEtcdClientCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("prometheus-etcd-client.%s.k8s.%s", key.ClusterID(&cr), bd),
-		TTL:              r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForServiceAccount(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		ClusterComponent: certs.ServiceAccountCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("service-actxount.%s.k8s.%s", key.ClusterID(&cr), bd),
-		TTL:              r.certTTL,
-	}
-}
-
-func (r *Resource) newSpecForWorker(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
-	return corev1alpha1.CertConfigSpecCert{
-		AllowBareDomains: true,
-		AltNames:         key.CertDefaultAltNames(r.clusterDomain),
-		ClusterComponent: certs.WorkerCert.String(),
-		ClusterID:        key.ClusterID(&cr),
-		CommonName:       fmt.Sprintf("worker.%s.k8s.%s", key.ClusterID(&cr), bd),
-		TTL:              r.certTTL,
-	}
-}
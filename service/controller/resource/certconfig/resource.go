@@ -1,14 +1,15 @@
 package certconfig
 
 import (
+	"reflect"
+
 	"github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
-	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
-	"github.com/giantswarm/cluster-operator/v5/service/internal/hamaster"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/certspec"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
 )
 
@@ -26,43 +27,28 @@ const (
 
 // Config represents the configuration used to create a new cloud config resource.
 type Config struct {
-	BaseDomain     basedomain.Interface
+	CertSpec       certspec.Interface
 	CtrlClient     ctrlClient.Client
-	HAMaster       hamaster.Interface
 	Logger         micrologger.Logger
 	ReleaseVersion releaseversion.Interface
-
-	APIIP         string
-	CertTTL       string
-	ClusterDomain string
-	Provider      string
 }
 
 // Resource implements the cloud config resource.
 type Resource struct {
-	baseDomain     basedomain.Interface
+	certSpec       certspec.Interface
 	ctrlClient     ctrlClient.Client
-	haMaster       hamaster.Interface
 	logger         micrologger.Logger
 	releaseVersion releaseversion.Interface
-
-	apiIP         string
-	certTTL       string
-	clusterDomain string
-	provider      string
 }
 
 // New creates a new configured cloud config resource.
 func New(config Config) (*Resource, error) {
-	if config.BaseDomain == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.BaseDomain must not be empty", config)
+	if config.CertSpec == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CertSpec must not be empty", config)
 	}
 	if config.CtrlClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
 	}
-	if config.HAMaster == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.HAMaster must not be empty", config)
-	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
@@ -70,30 +56,11 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.ReleaseVersion must not be empty", config)
 	}
 
-	if config.APIIP == "" {
-		return nil, microerror.Maskf(invalidConfigError, "%T.APIIP must not be empty", config)
-	}
-	if config.CertTTL == "" {
-		return nil, microerror.Maskf(invalidConfigError, "%T.CertTTL must not be empty", config)
-	}
-	if config.ClusterDomain == "" {
-		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterDomain must not be empty", config)
-	}
-	if config.Provider == "" {
-		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
-	}
-
 	r := &Resource{
-		baseDomain:     config.BaseDomain,
+		certSpec:       config.CertSpec,
 		ctrlClient:     config.CtrlClient,
-		haMaster:       config.HAMaster,
 		logger:         config.Logger,
 		releaseVersion: config.ReleaseVersion,
-
-		apiIP:         config.APIIP,
-		certTTL:       config.CertTTL,
-		clusterDomain: config.ClusterDomain,
-		provider:      config.Provider,
 	}
 
 	return r, nil
@@ -127,7 +94,31 @@ func getCertConfigByName(list []*v1alpha1.CertConfig, name string) (*v1alpha1.Ce
 func isCertConfigModified(a, b *v1alpha1.CertConfig) bool {
 	aVersion := key.CertConfigCertOperatorVersion(*a)
 	bVersion := key.CertConfigCertOperatorVersion(*b)
-	return aVersion != bVersion
+	if aVersion != bVersion {
+		return true
+	}
+
+	if key.CertConfigRotationGeneration(a) != key.CertConfigRotationGeneration(b) {
+		return true
+	}
+
+	return !reflect.DeepEqual(normalizeCertSpec(a.Spec.Cert), normalizeCertSpec(b.Spec.Cert))
+}
+
+// normalizeCertSpec sets empty lists to nil, since empty lists are omitted
+// when CertConfig CRs are stored and are returned as nil by the API.
+func normalizeCertSpec(c v1alpha1.CertConfigSpecCert) v1alpha1.CertConfigSpecCert {
+	if len(c.AltNames) == 0 {
+		c.AltNames = nil
+	}
+	if len(c.IPSANs) == 0 {
+		c.IPSANs = nil
+	}
+	if len(c.Organizations) == 0 {
+		c.Organizations = nil
+	}
+
+	return c
 }
 
 func toCertConfigs(v interface{}) ([]*v1alpha1.CertConfig, error) {
@@ -0,0 +1,289 @@
+package certconfig
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+type fakeCertSpec struct {
+	specs []corev1alpha1.CertConfigSpecCert
+}
+
+func (f fakeCertSpec) Specs(ctx context.Context, cr apiv1beta1.Cluster) ([]corev1alpha1.CertConfigSpecCert, error) {
+	return f.specs, nil
+}
+
+type fakeReleaseVersion struct {
+	version string
+}
+
+func (f fakeReleaseVersion) Apps(ctx context.Context, obj interface{}) (map[string]releaseversion.ReleaseApp, error) {
+	return nil, nil
+}
+
+func (f fakeReleaseVersion) ComponentVersion(ctx context.Context, obj interface{}) (map[string]releaseversion.ReleaseComponent, error) {
+	return map[string]releaseversion.ReleaseComponent{
+		releaseversion.CertOperator: {Version: f.version},
+	}, nil
+}
+
+func Test_Resource_EnsureCreated_Update(t *testing.T) {
+	current := corev1alpha1.CertConfigSpecCert{
+		AltNames:         []string{"api.8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io"},
+		ClusterComponent: "api",
+		ClusterID:        "8y5ck",
+		CommonName:       "api.8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io",
+		IPSANs:           []string{"172.31.0.1"},
+		TTL:              "720h",
+	}
+
+	testCases := []struct {
+		name          string
+		desired       func(c corev1alpha1.CertConfigSpecCert) corev1alpha1.CertConfigSpecCert
+		expectUpdated bool
+	}{
+		{
+			name: "case 0: unchanged spec is not updated",
+			desired: func(c corev1alpha1.CertConfigSpecCert) corev1alpha1.CertConfigSpecCert {
+				return c
+			},
+		},
+		{
+			name: "case 1: empty organizations are not updated",
+			desired: func(c corev1alpha1.CertConfigSpecCert) corev1alpha1.CertConfigSpecCert {
+				c.Organizations = []string{}
+				return c
+			},
+		},
+		{
+			name: "case 2: changed TTL is updated",
+			desired: func(c corev1alpha1.CertConfigSpecCert) corev1alpha1.CertConfigSpecCert {
+				c.TTL = "2160h"
+				return c
+			},
+			expectUpdated: true,
+		},
+		{
+			name: "case 3: changed alt names are updated",
+			desired: func(c corev1alpha1.CertConfigSpecCert) corev1alpha1.CertConfigSpecCert {
+				c.AltNames = append([]string{"api.example.com"}, c.AltNames...)
+				return c
+			},
+			expectUpdated: true,
+		},
+		{
+			name: "case 4: changed IP SANs are updated",
+			desired: func(c corev1alpha1.CertConfigSpecCert) corev1alpha1.CertConfigSpecCert {
+				c.IPSANs = append(c.IPSANs, "10.0.0.1")
+				return c
+			},
+			expectUpdated: true,
+		},
+		{
+			name: "case 5: changed organizations are updated",
+			desired: func(c corev1alpha1.CertConfigSpecCert) corev1alpha1.CertConfigSpecCert {
+				c.Organizations = []string{"system:masters"}
+				return c
+			},
+			expectUpdated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := resourcecanceledcontext.NewContext(context.Background(), make(chan struct{}))
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			cluster := newTestCluster(key.CertBackendCertConfig)
+
+			existing := newCertConfig("3.0.0", *cluster, current)
+			err := ctrlClient.Create(ctx, existing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resourceVersion := existing.ResourceVersion
+
+			desired := tc.desired(*current.DeepCopy())
+
+			r, err := New(Config{
+				CertSpec:       fakeCertSpec{specs: []corev1alpha1.CertConfigSpecCert{desired}},
+				CtrlClient:     ctrlClient,
+				Logger:         microloggertest.New(),
+				ReleaseVersion: fakeReleaseVersion{version: "3.0.0"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = r.EnsureCreated(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var actual corev1alpha1.CertConfig
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: existing.Name, Namespace: existing.Namespace}, &actual)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			updated := actual.ResourceVersion != resourceVersion
+			if updated != tc.expectUpdated {
+				t.Fatalf("updated == %t, want %t", updated, tc.expectUpdated)
+			}
+			if !reflect.DeepEqual(normalizeCertSpec(actual.Spec.Cert), normalizeCertSpec(desired)) {
+				t.Fatalf("spec == %#v, want %#v", actual.Spec.Cert, desired)
+			}
+		})
+	}
+}
+
+func Test_Resource_EnsureCreated_Backend(t *testing.T) {
+	spec := corev1alpha1.CertConfigSpecCert{
+		ClusterComponent: "api",
+		ClusterID:        "8y5ck",
+		CommonName:       "api.8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io",
+		TTL:              "720h",
+	}
+
+	testCases := []struct {
+		name                string
+		certBackend         string
+		certOperatorVersion string
+		certsSecret         bool
+		expectCertConfig    bool
+		expectCanceled      bool
+		errorMatcher        func(error) bool
+	}{
+		{
+			name:                "case 0: certconfig backend without cert-operator version returns error",
+			certBackend:         key.CertBackendCertConfig,
+			certOperatorVersion: "",
+			expectCertConfig:    true,
+			errorMatcher:        IsNotFound,
+		},
+		{
+			name:                "case 1: unrecorded backend keeps CertConfig",
+			certBackend:         "",
+			certOperatorVersion: "3.0.0",
+			expectCertConfig:    true,
+			expectCanceled:      true,
+		},
+		{
+			name:                "case 2: cert-manager backend keeps CertConfig until certificates are issued",
+			certBackend:         key.CertBackendCertManager,
+			certOperatorVersion: "",
+			expectCertConfig:    true,
+			expectCanceled:      true,
+		},
+		{
+			name:                "case 3: cert-manager backend deletes CertConfig once certificates are issued",
+			certBackend:         key.CertBackendCertManager,
+			certOperatorVersion: "",
+			certsSecret:         true,
+			expectCertConfig:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := resourcecanceledcontext.NewContext(context.Background(), make(chan struct{}))
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			cluster := newTestCluster(tc.certBackend)
+
+			existing := newCertConfig("3.0.0", *cluster, spec)
+			err := ctrlClient.Create(ctx, existing)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.certsSecret {
+				err = ctrlClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.CertManagerCertsSecretName(cluster, spec.ClusterComponent),
+						Namespace: cluster.Namespace,
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r, err := New(Config{
+				CertSpec:       fakeCertSpec{specs: []corev1alpha1.CertConfigSpecCert{spec}},
+				CtrlClient:     ctrlClient,
+				Logger:         microloggertest.New(),
+				ReleaseVersion: fakeReleaseVersion{version: tc.certOperatorVersion},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = r.EnsureCreated(ctx, cluster)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if canceled := resourcecanceledcontext.IsCanceled(ctx); canceled != tc.expectCanceled {
+				t.Fatalf("canceled == %t, want %t", canceled, tc.expectCanceled)
+			}
+
+			var actual corev1alpha1.CertConfig
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: existing.Name, Namespace: existing.Namespace}, &actual)
+			if apierrors.IsNotFound(err) {
+				if tc.expectCertConfig {
+					t.Fatalf("CertConfig was deleted, want it kept")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if !tc.expectCertConfig {
+				t.Fatalf("CertConfig was kept, want it deleted")
+			}
+		})
+	}
+}
+
+func newTestCluster(certBackend string) *apiv1beta1.Cluster {
+	cluster := &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck",
+			Namespace: "org-giantswarm",
+			Labels: map[string]string{
+				label.Cluster:      "8y5ck",
+				label.Organization: "giantswarm",
+			},
+		},
+	}
+
+	if certBackend != "" {
+		cluster.Annotations = map[string]string{
+			annotation.CertBackend: certBackend,
+		}
+	}
+
+	return cluster
+}
@@ -0,0 +1,376 @@
+package certmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/hamaster"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	switch key.CertBackend(&cr) {
+	case key.CertBackendCertManager:
+		// Certificates are issued below.
+	case key.CertBackendCertConfig:
+		// Tenant Clusters using the certconfig backend get their certificates
+		// from cert-operator. We make sure nothing is left over from
+		// cert-manager in case the backend was switched back.
+		r.logger.Debugf(ctx, "certificates are issued by cert-operator")
+
+		err = r.deleteAll(ctx, cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	default:
+		r.logger.Debugf(ctx, "certificate backend not recorded yet")
+		r.logger.Debugf(ctx, "canceling resource")
+		resourcecanceledcontext.SetCanceled(ctx)
+		return nil
+	}
+
+	specs, err := r.certSpec.Specs(ctx, cr)
+	if hamaster.IsNotFound(err) {
+		r.logger.Debugf(ctx, "control plane CR not available yet")
+		r.logger.Debugf(ctx, "canceling resource")
+		resourcecanceledcontext.SetCanceled(ctx)
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	bd, err := r.baseDomain.BaseDomain(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	{
+		ready, err := r.ensureCA(ctx, cr, bd)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if !ready {
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+			return nil
+		}
+	}
+
+	{
+		r.logger.Debugf(ctx, "ensuring cert-manager issuers and certificates")
+
+		desired := newIssuers(cr)
+		for _, s := range specs {
+			desired = append(desired, newCertificate(cr, s))
+		}
+
+		for _, d := range desired {
+			err = r.ensureObject(ctx, d)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		r.logger.Debugf(ctx, "ensured cert-manager issuers and certificates")
+	}
+
+	{
+		r.logger.Debugf(ctx, "ensuring certificate secrets")
+
+		for _, s := range specs {
+			var tls corev1.Secret
+			err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: key.CertManagerTLSSecretName(&cr, s.ClusterComponent), Namespace: cr.Namespace}, &tls)
+			if apierrors.IsNotFound(err) {
+				r.logger.Debugf(ctx, "waiting for cert-manager to issue certificate %#q", s.ClusterComponent)
+				continue
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			err = r.ensureSecret(ctx, newCertsSecret(cr, s.ClusterComponent, tls))
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		r.logger.Debugf(ctx, "ensured certificate secrets")
+	}
+
+	// Certificates which are not desired anymore, e.g. of etcd members removed
+	// from the control plane, are cleaned up.
+	{
+		components := map[string]bool{}
+		for _, s := range specs {
+			components[s.ClusterComponent] = true
+		}
+
+		err = r.deleteCertificates(ctx, cr, func(component string) bool { return !components[component] })
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// ensureCA ensures the CA of the tenant cluster and returns whether it is
+// ready to sign certificates. Tenant Clusters created with the cert-manager
+// backend get a self-signed CA Certificate. Tenant Clusters moved from the
+// certconfig backend keep the CA cert-operator issued their certificates with,
+// so that existing clients keep trusting the API. That CA has to be imported
+// into the CA Secret. No CA Certificate is managed for imported CAs, since
+// cert-manager would replace them.
+func (r *Resource) ensureCA(ctx context.Context, cr apiv1beta1.Cluster, bd string) (bool, error) {
+	caCertificate := newCACertificate(cr, bd, r.caTTL)
+
+	{
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(certificateGVK)
+
+		err := r.ctrlClient.Get(ctx, client.ObjectKeyFromObject(caCertificate), current)
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		} else {
+			err = r.ensureObject(ctx, caCertificate)
+			if err != nil {
+				return false, microerror.Mask(err)
+			}
+
+			return true, nil
+		}
+	}
+
+	var caSecret corev1.Secret
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: key.CertManagerCAName(&cr), Namespace: cr.Namespace}, &caSecret)
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		} else {
+			return r.verifyImportedCA(ctx, cr, caSecret)
+		}
+	}
+
+	var certConfigs corev1alpha1.CertConfigList
+	{
+		err := r.ctrlClient.List(
+			ctx,
+			&certConfigs,
+			client.InNamespace(cr.Namespace),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr)},
+		)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+	}
+
+	if len(certConfigs.Items) > 0 {
+		r.logger.Debugf(ctx, "waiting for the CA of cert-operator to be imported into secret %#q", fmt.Sprintf("%s/%s", cr.Namespace, key.CertManagerCAName(&cr)))
+		return false, nil
+	}
+
+	err := r.ensureObject(ctx, caCertificate)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
+
+// verifyImportedCA checks that the imported CA holds a key pair and, as long
+// as the certificates of cert-operator exist, that it is the CA they were
+// issued with.
+func (r *Resource) verifyImportedCA(ctx context.Context, cr apiv1beta1.Cluster, caSecret corev1.Secret) (bool, error) {
+	if len(caSecret.Data[corev1.TLSCertKey]) == 0 || len(caSecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		r.logger.Debugf(ctx, "waiting for secret %#q to contain the keys %#q and %#q of the imported CA", fmt.Sprintf("%s/%s", caSecret.Namespace, caSecret.Name), corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		return false, nil
+	}
+
+	var apiSecret corev1.Secret
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: key.APISecretName(&cr), Namespace: cr.Namespace}, &apiSecret)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		}
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(caSecret.Data[corev1.TLSCertKey]), bytes.TrimSpace(apiSecret.Data["ca"])) {
+		r.logger.Debugf(ctx, "waiting for secret %#q to contain the CA of cert-operator", fmt.Sprintf("%s/%s", caSecret.Namespace, caSecret.Name))
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ensureObject creates the given cert-manager object or updates its spec in
+// case it changed.
+func (r *Resource) ensureObject(ctx context.Context, desired *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(desired.GroupVersionKind())
+
+	err := r.ctrlClient.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	if apierrors.IsNotFound(err) {
+		r.logger.Debugf(ctx, "creating %s %#q", desired.GetKind(), desired.GetName())
+
+		err = r.ctrlClient.Create(ctx, desired)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "created %s %#q", desired.GetKind(), desired.GetName())
+
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if reflect.DeepEqual(current.Object["spec"], desired.Object["spec"]) && reflect.DeepEqual(current.GetLabels(), desired.GetLabels()) {
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "updating %s %#q", desired.GetKind(), desired.GetName())
+
+	current.Object["spec"] = desired.Object["spec"]
+	current.SetLabels(desired.GetLabels())
+
+	err = r.ctrlClient.Update(ctx, current)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "updated %s %#q", desired.GetKind(), desired.GetName())
+
+	return nil
+}
+
+// ensureSecret creates the given certificate Secret or updates it in case the
+// certificate was reissued.
+func (r *Resource) ensureSecret(ctx context.Context, desired *corev1.Secret) error {
+	var current corev1.Secret
+
+	err := r.ctrlClient.Get(ctx, client.ObjectKeyFromObject(desired), &current)
+	if apierrors.IsNotFound(err) {
+		r.logger.Debugf(ctx, "creating secret %#q", desired.Name)
+
+		err = r.ctrlClient.Create(ctx, desired)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "created secret %#q", desired.Name)
+
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if reflect.DeepEqual(current.Data, desired.Data) && reflect.DeepEqual(current.Labels, desired.Labels) {
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "updating secret %#q", desired.Name)
+
+	current.Data = desired.Data
+	current.Labels = desired.Labels
+
+	err = r.ctrlClient.Update(ctx, &current)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "updated secret %#q", desired.Name)
+
+	return nil
+}
+
+// deleteCertificates deletes the Certificates of the tenant cluster for which
+// the given filter returns true, along with the Secrets holding them.
+func (r *Resource) deleteCertificates(ctx context.Context, cr apiv1beta1.Cluster, filter func(component string) bool) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(certificateGVK.GroupVersion().WithKind(certificateGVK.Kind + "List"))
+
+	err := r.ctrlClient.List(
+		ctx,
+		list,
+		client.InNamespace(cr.Namespace),
+		client.MatchingLabels{label.Cluster: key.ClusterID(&cr), label.ManagedBy: project.Name()},
+		client.HasLabels{label.Certificate},
+	)
+	if meta.IsNoMatchError(err) {
+		// cert-manager is not installed, so there is nothing to delete.
+		r.logger.Debugf(ctx, "cert-manager CRDs not installed")
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for i := range list.Items {
+		c := &list.Items[i]
+		component := c.GetLabels()[label.Certificate]
+		if !filter(component) {
+			continue
+		}
+
+		r.logger.Debugf(ctx, "deleting certificate %#q", component)
+
+		err = r.deleteIgnoringNotFound(ctx, c)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		// cert-manager does not delete the Secrets of deleted Certificates.
+		for _, name := range []string{key.CertManagerTLSSecretName(&cr, component), key.CertManagerCertsSecretName(&cr, component)} {
+			s := &corev1.Secret{}
+			s.SetName(name)
+			s.SetNamespace(cr.Namespace)
+
+			err = r.deleteIgnoringNotFound(ctx, s)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		r.logger.Debugf(ctx, "deleted certificate %#q", component)
+	}
+
+	return nil
+}
+
+func (r *Resource) deleteIgnoringNotFound(ctx context.Context, obj client.Object) error {
+	err := r.ctrlClient.Delete(ctx, obj)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
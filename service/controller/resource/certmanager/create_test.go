@@ -0,0 +1,157 @@
+package certmanager
+
+import (
+	"context"
+	"testing"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_Resource_ensureCA(t *testing.T) {
+	testCases := []struct {
+		name          string
+		caCertificate bool
+		caSecret      map[string][]byte
+		apiSecretCA   string
+		certConfig    bool
+
+		expectReady         bool
+		expectCACertificate bool
+	}{
+		{
+			name:                "case 0: managed CA is kept",
+			caCertificate:       true,
+			expectReady:         true,
+			expectCACertificate: true,
+		},
+		{
+			name: "case 1: imported CA of cert-operator is reused",
+			caSecret: map[string][]byte{
+				corev1.TLSCertKey:       []byte("ca"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+			apiSecretCA: "ca\n",
+			certConfig:  true,
+			expectReady: true,
+		},
+		{
+			name: "case 2: imported CA not matching cert-operator is not used",
+			caSecret: map[string][]byte{
+				corev1.TLSCertKey:       []byte("other"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+			apiSecretCA: "ca",
+			certConfig:  true,
+			expectReady: false,
+		},
+		{
+			name: "case 3: imported CA without private key is not used",
+			caSecret: map[string][]byte{
+				corev1.TLSCertKey: []byte("ca"),
+			},
+			certConfig:  true,
+			expectReady: false,
+		},
+		{
+			name:        "case 4: cluster moved from certconfig waits for imported CA",
+			certConfig:  true,
+			expectReady: false,
+		},
+		{
+			name:                "case 5: new cluster gets self-signed CA",
+			expectReady:         true,
+			expectCACertificate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			cr := newCluster()
+
+			var objs []client.Object
+			if tc.caCertificate {
+				objs = append(objs, newCACertificate(cr, "gauss.eu-central-1.aws.gigantic.io", "87600h"))
+			}
+			if tc.caSecret != nil {
+				objs = append(objs, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.CertManagerCAName(&cr),
+						Namespace: cr.Namespace,
+					},
+					Data: tc.caSecret,
+				})
+			}
+			if tc.apiSecretCA != "" {
+				objs = append(objs, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.APISecretName(&cr),
+						Namespace: cr.Namespace,
+					},
+					Data: map[string][]byte{
+						"ca": []byte(tc.apiSecretCA),
+					},
+				})
+			}
+			if tc.certConfig {
+				objs = append(objs, &corev1alpha1.CertConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.CertConfigName(&cr, "api"),
+						Namespace: cr.Namespace,
+						Labels: map[string]string{
+							label.Cluster: key.ClusterID(&cr),
+						},
+					},
+				})
+			}
+			for _, o := range objs {
+				err := ctrlClient.Create(ctx, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r := &Resource{
+				ctrlClient: ctrlClient,
+				logger:     microloggertest.New(),
+
+				caTTL: "87600h",
+			}
+
+			ready, err := r.ensureCA(ctx, cr, "gauss.eu-central-1.aws.gigantic.io")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ready != tc.expectReady {
+				t.Fatalf("ready == %t, want %t", ready, tc.expectReady)
+			}
+
+			caCertificate := &unstructured.Unstructured{}
+			caCertificate.SetGroupVersionKind(certificateGVK)
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: key.CertManagerCAName(&cr), Namespace: cr.Namespace}, caCertificate)
+			if apierrors.IsNotFound(err) {
+				if tc.expectCACertificate {
+					t.Fatalf("CA Certificate not found, want it to exist")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if !tc.expectCACertificate {
+				t.Fatalf("CA Certificate found, want it to not exist")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package certmanager
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.deleteAll(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// deleteAll deletes all certificates, Issuers and the CA of the tenant cluster
+// issued by cert-manager.
+func (r *Resource) deleteAll(ctx context.Context, cr apiv1beta1.Cluster) error {
+	err := r.deleteCertificates(ctx, cr, func(string) bool { return true })
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var objs []*unstructured.Unstructured
+	{
+		objs = append(objs, newCACertificate(cr, "", ""))
+		objs = append(objs, newIssuers(cr)...)
+	}
+
+	for _, o := range objs {
+		err = r.deleteIgnoringNotFound(ctx, o)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	s := &corev1.Secret{}
+	s.SetName(key.CertManagerCAName(&cr))
+	s.SetNamespace(cr.Namespace)
+
+	err = r.deleteIgnoringNotFound(ctx, s)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package certmanager
+
+import (
+	"fmt"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+var (
+	// We do not vendor the cert-manager API and use unstructured objects
+	// instead, since only a few fields of its CRs are of interest.
+	certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	issuerGVK      = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}
+)
+
+func newLabels(cr apiv1beta1.Cluster) map[string]string {
+	return map[string]string{
+		label.Cluster:      key.ClusterID(&cr),
+		label.ManagedBy:    project.Name(),
+		label.Organization: key.OrganizationID(&cr),
+	}
+}
+
+func newObject(gvk schema.GroupVersionKind, cr apiv1beta1.Cluster, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(name)
+	u.SetNamespace(cr.Namespace)
+	u.SetLabels(newLabels(cr))
+	u.Object["spec"] = spec
+
+	return u
+}
+
+// newIssuers returns the self-signed Issuer bootstrapping the CA of the
+// tenant cluster and the CA Issuer signing all its certificates.
+func newIssuers(cr apiv1beta1.Cluster) []*unstructured.Unstructured {
+	return []*unstructured.Unstructured{
+		newObject(issuerGVK, cr, key.CertManagerSelfSignedIssuerName(&cr), map[string]interface{}{
+			"selfSigned": map[string]interface{}{},
+		}),
+		newObject(issuerGVK, cr, key.CertManagerCAName(&cr), map[string]interface{}{
+			"ca": map[string]interface{}{
+				"secretName": key.CertManagerCAName(&cr),
+			},
+		}),
+	}
+}
+
+// newCACertificate returns the Certificate of the CA of the tenant cluster.
+func newCACertificate(cr apiv1beta1.Cluster, bd string, caTTL string) *unstructured.Unstructured {
+	return newObject(certificateGVK, cr, key.CertManagerCAName(&cr), map[string]interface{}{
+		"commonName": fmt.Sprintf("%s.k8s.%s", key.ClusterID(&cr), bd),
+		"duration":   caTTL,
+		"isCA":       true,
+		"issuerRef": map[string]interface{}{
+			"kind": issuerGVK.Kind,
+			"name": key.CertManagerSelfSignedIssuerName(&cr),
+		},
+		"secretName": key.CertManagerCAName(&cr),
+	})
+}
+
+// newCertificate renders the given certificate spec as cert-manager
+// Certificate signed by the CA Issuer of the tenant cluster.
+func newCertificate(cr apiv1beta1.Cluster, spec corev1alpha1.CertConfigSpecCert) *unstructured.Unstructured {
+	s := map[string]interface{}{
+		"commonName": spec.CommonName,
+		"issuerRef": map[string]interface{}{
+			"kind": issuerGVK.Kind,
+			"name": key.CertManagerCAName(&cr),
+		},
+		"secretName": key.CertManagerTLSSecretName(&cr, spec.ClusterComponent),
+		"usages": []interface{}{
+			"digital signature",
+			"key encipherment",
+			"server auth",
+			"client auth",
+		},
+	}
+
+	// The common name is part of the DNS names as well since clients ignore
+	// the common name when verifying the server certificate.
+	dnsNames := []interface{}{spec.CommonName}
+	for _, n := range spec.AltNames {
+		dnsNames = append(dnsNames, n)
+	}
+	s["dnsNames"] = dnsNames
+
+	if len(spec.IPSANs) > 0 {
+		var ips []interface{}
+		for _, ip := range spec.IPSANs {
+			ips = append(ips, ip)
+		}
+		s["ipAddresses"] = ips
+	}
+	if len(spec.Organizations) > 0 {
+		var orgs []interface{}
+		for _, o := range spec.Organizations {
+			orgs = append(orgs, o)
+		}
+		s["subject"] = map[string]interface{}{
+			"organizations": orgs,
+		}
+	}
+	if spec.TTL != "" {
+		s["duration"] = spec.TTL
+	}
+
+	u := newObject(certificateGVK, cr, key.CertConfigName(&cr, spec.ClusterComponent), s)
+
+	labels := u.GetLabels()
+	labels[label.Certificate] = spec.ClusterComponent
+	u.SetLabels(labels)
+
+	return u
+}
+
+// newCertsSecret converts the Secret written by cert-manager into the format
+// of the certs searcher, which is what cert-operator writes as well.
+func newCertsSecret(cr apiv1beta1.Cluster, component string, tls corev1.Secret) *corev1.Secret {
+	labels := newLabels(cr)
+	labels[label.Certificate] = component
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.CertManagerCertsSecretName(&cr, component),
+			Namespace: cr.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string][]byte{
+			"ca":  tls.Data["ca.crt"],
+			"crt": tls.Data[corev1.TLSCertKey],
+			"key": tls.Data[corev1.TLSPrivateKeyKey],
+		},
+	}
+}
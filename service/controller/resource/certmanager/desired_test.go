@@ -0,0 +1,107 @@
+package certmanager
+
+import (
+	"reflect"
+	"testing"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+)
+
+func Test_newCertificate(t *testing.T) {
+	cr := newCluster()
+
+	spec := corev1alpha1.CertConfigSpecCert{
+		AltNames:         []string{"kubernetes", "master.8y5ck"},
+		ClusterComponent: "api",
+		ClusterID:        "8y5ck",
+		CommonName:       "api.8y5ck.k8s.example.com",
+		IPSANs:           []string{"172.31.0.1", "127.0.0.1"},
+		Organizations:    []string{"system:masters"},
+		TTL:              "720h",
+	}
+
+	c := newCertificate(cr, spec)
+
+	if c.GetName() != "8y5ck-api" {
+		t.Fatalf("name == %#q, want %#q", c.GetName(), "8y5ck-api")
+	}
+	if c.GetLabels()[label.Certificate] != "api" {
+		t.Fatalf("certificate label == %#q, want %#q", c.GetLabels()[label.Certificate], "api")
+	}
+
+	expected := map[string]interface{}{
+		"commonName": "api.8y5ck.k8s.example.com",
+		"dnsNames":   []interface{}{"api.8y5ck.k8s.example.com", "kubernetes", "master.8y5ck"},
+		"duration":   "720h",
+		"ipAddresses": []interface{}{
+			"172.31.0.1",
+			"127.0.0.1",
+		},
+		"issuerRef": map[string]interface{}{
+			"kind": "Issuer",
+			"name": "8y5ck-ca",
+		},
+		"secretName": "8y5ck-api-tls",
+		"subject": map[string]interface{}{
+			"organizations": []interface{}{"system:masters"},
+		},
+		"usages": []interface{}{
+			"digital signature",
+			"key encipherment",
+			"server auth",
+			"client auth",
+		},
+	}
+
+	if !reflect.DeepEqual(c.Object["spec"], expected) {
+		t.Fatalf("spec == %#v, want %#v", c.Object["spec"], expected)
+	}
+}
+
+func Test_newCertsSecret(t *testing.T) {
+	cr := newCluster()
+
+	tls := corev1.Secret{
+		Data: map[string][]byte{
+			"ca.crt":                []byte("ca"),
+			corev1.TLSCertKey:       []byte("crt"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+
+	s := newCertsSecret(cr, "worker", tls)
+
+	if s.Name != "8y5ck-worker-certs" || s.Namespace != "org-giantswarm" {
+		t.Fatalf("secret == %s/%s, want %s/%s", s.Namespace, s.Name, "org-giantswarm", "8y5ck-worker-certs")
+	}
+	if s.Labels[label.Certificate] != "worker" || s.Labels[label.Cluster] != "8y5ck" {
+		t.Fatalf("labels == %#v, want certificate and cluster labels", s.Labels)
+	}
+
+	expected := map[string][]byte{
+		"ca":  []byte("ca"),
+		"crt": []byte("crt"),
+		"key": []byte("key"),
+	}
+	if !reflect.DeepEqual(s.Data, expected) {
+		t.Fatalf("data == %#v, want %#v", s.Data, expected)
+	}
+}
+
+func newCluster() apiv1beta1.Cluster {
+	return apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck",
+			Namespace: "org-giantswarm",
+			Labels: map[string]string{
+				label.Cluster:      "8y5ck",
+				label.Organization: "giantswarm",
+			},
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package certmanager
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/certspec"
+)
+
+const (
+	Name = "certmanager"
+)
+
+type Config struct {
+	BaseDomain basedomain.Interface
+	CertSpec   certspec.Interface
+	CtrlClient ctrlClient.Client
+	Logger     micrologger.Logger
+
+	CATTL string
+}
+
+// Resource issues the certificates of tenant clusters using the cert-manager
+// backend. Every tenant cluster gets its own CA Issuer. The certificates are
+// rendered from the same specs as the CertConfig CRs of the certconfig
+// backend. The Secrets written by cert-manager are converted into the format
+// the certs searcher expects, so that consumers do not need to know which
+// backend issued a certificate.
+type Resource struct {
+	baseDomain basedomain.Interface
+	certSpec   certspec.Interface
+	ctrlClient ctrlClient.Client
+	logger     micrologger.Logger
+
+	caTTL string
+}
+
+func New(config Config) (*Resource, error) {
+	if config.BaseDomain == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.BaseDomain must not be empty", config)
+	}
+	if config.CertSpec == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CertSpec must not be empty", config)
+	}
+	if config.CtrlClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.CATTL == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CATTL must not be empty", config)
+	}
+
+	r := &Resource{
+		baseDomain: config.BaseDomain,
+		certSpec:   config.CertSpec,
+		ctrlClient: config.CtrlClient,
+		logger:     config.Logger,
+
+		caTTL: config.CATTL,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
@@ -0,0 +1,438 @@
+package certrotation
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+var (
+	// We do not vendor the cert-manager API and list its Certificates as
+	// unstructured objects instead.
+	certificateListGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "CertificateList"}
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	requested := key.CertificatesRotationRequested(&cr)
+	if requested == "" {
+		r.logger.Debugf(ctx, "no certificate rotation requested")
+		return nil
+	}
+
+	// A new rotation generation was requested. We record the generation on
+	// the Cluster CR and start over so that the certconfig resource bumps the
+	// rotation generation of all CertConfig CRs.
+	if key.CertificatesRotationGeneration(&cr) != requested || conditions.Get(&cr, key.CertificatesRotatedCondition) == nil {
+		err = r.startRotation(ctx, cr, requested)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "canceling reconciliation")
+		reconciliationcanceledcontext.SetCanceled(ctx)
+		return nil
+	}
+
+	if conditions.IsTrue(&cr, key.CertificatesRotatedCondition) {
+		r.logger.Debugf(ctx, "certificates of rotation generation %#q already rotated", requested)
+		return nil
+	}
+
+	started := conditions.GetLastTransitionTime(&cr, key.CertificatesRotatedCondition)
+
+	r.logger.Debugf(ctx, "rotating certificates of rotation generation %#q started at %s", requested, started)
+
+	var secrets corev1.SecretList
+	{
+		err = r.ctrlClient.List(
+			ctx,
+			&secrets,
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr)},
+			client.HasLabels{label.Certificate},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	secretsByComponent := map[string]corev1.Secret{}
+	for _, s := range secrets.Items {
+		secretsByComponent[s.Labels[label.Certificate]] = s
+	}
+
+	var reissued, total int
+	switch key.CertBackend(&cr) {
+	case key.CertBackendCertConfig:
+		reissued, total, err = r.reissueCertConfigs(ctx, cr, requested, started, secretsByComponent)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	case key.CertBackendCertManager:
+		reissued, total, err = r.reissueCertificates(ctx, cr, started, secretsByComponent)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	default:
+		r.logger.Debugf(ctx, "waiting for certificate backend to be recorded")
+		return nil
+	}
+
+	if reissued < total {
+		message := fmt.Sprintf("Rotation generation %s: %d of %d certificates reissued.", requested, reissued, total)
+
+		err = r.updateCondition(ctx, cr, func(cl *apiv1beta1.Cluster) {
+			conditions.MarkFalse(cl, key.CertificatesRotatedCondition, key.CertificatesRotationInProgressReason, apiv1beta1.ConditionSeverityInfo, "%s", message)
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	// The kubeconfig resources regenerate the kubeconfig Secrets of all
+	// variants, their mirrors and the OIDC kubeconfig from the certificate
+	// Secrets. Tenant clients are built from the certificate Secrets on every
+	// reconciliation, so there is no cached client using the old certificates
+	// once all kubeconfigs are up to date.
+	{
+		regenerated, err := r.kubeConfigsRegenerated(ctx, cr, secretsByComponent)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if !regenerated {
+			message := fmt.Sprintf("Rotation generation %s: all certificates reissued, waiting for kubeconfigs.", requested)
+
+			err = r.updateCondition(ctx, cr, func(cl *apiv1beta1.Cluster) {
+				conditions.MarkFalse(cl, key.CertificatesRotatedCondition, key.CertificatesRotationInProgressReason, apiv1beta1.ConditionSeverityInfo, "%s", message)
+			})
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			return nil
+		}
+	}
+
+	err = r.updateCondition(ctx, cr, func(cl *apiv1beta1.Cluster) {
+		conditions.MarkTrue(cl, key.CertificatesRotatedCondition)
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.event.Emit(ctx, &cr, "CertificatesRotated", fmt.Sprintf("rotated all certificates of rotation generation %s", requested))
+
+	r.logger.Debugf(ctx, "rotated certificates of rotation generation %#q", requested)
+
+	return nil
+}
+
+// reissueCertConfigs replaces the certificate Secrets of cert-operator issued
+// before the rotation started and returns how many of the certificates of the
+// tenant cluster were reissued.
+func (r *Resource) reissueCertConfigs(ctx context.Context, cr apiv1beta1.Cluster, requested string, started *metav1.Time, secretsByComponent map[string]corev1.Secret) (int, int, error) {
+	var certConfigs corev1alpha1.CertConfigList
+	{
+		err := r.ctrlClient.List(
+			ctx,
+			&certConfigs,
+			client.InNamespace(cr.Namespace),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr)},
+		)
+		if err != nil {
+			return 0, 0, microerror.Mask(err)
+		}
+	}
+
+	var reissued int
+	for _, c := range certConfigs.Items {
+		component := c.Spec.Cert.ClusterComponent
+
+		if key.CertConfigRotationGeneration(&c) != requested {
+			r.logger.Debugf(ctx, "waiting for rotation generation of CertConfig CR %#q to be bumped", c.Name)
+			continue
+		}
+
+		s, ok := secretsByComponent[component]
+		if !ok {
+			r.logger.Debugf(ctx, "waiting for cert-operator to reissue certificate %#q", component)
+			continue
+		}
+
+		// Certificates issued before the rotation started are deleted so that
+		// cert-operator issues new ones for the bumped CertConfig CRs.
+		if s.CreationTimestamp.Before(started) {
+			err := r.deleteSecret(ctx, s, component)
+			if err != nil {
+				return 0, 0, microerror.Mask(err)
+			}
+
+			continue
+		}
+
+		reissued++
+	}
+
+	return reissued, len(certConfigs.Items), nil
+}
+
+// reissueCertificates replaces the Secrets cert-manager issued before the
+// rotation started and returns how many of the certificates of the tenant
+// cluster were reissued. cert-manager issues a certificate again as soon as
+// its Secret is gone. A certificate counts as reissued once the certmanager
+// resource converted the new Secret into the format of the certs searcher.
+func (r *Resource) reissueCertificates(ctx context.Context, cr apiv1beta1.Cluster, started *metav1.Time, secretsByComponent map[string]corev1.Secret) (int, int, error) {
+	certificates := &unstructured.UnstructuredList{}
+	{
+		certificates.SetGroupVersionKind(certificateListGVK)
+
+		err := r.ctrlClient.List(
+			ctx,
+			certificates,
+			client.InNamespace(cr.Namespace),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr)},
+			client.HasLabels{label.Certificate},
+		)
+		if err != nil {
+			return 0, 0, microerror.Mask(err)
+		}
+	}
+
+	var reissued int
+	for _, c := range certificates.Items {
+		component := c.GetLabels()[label.Certificate]
+
+		var tls corev1.Secret
+		{
+			err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: key.CertManagerTLSSecretName(&cr, component), Namespace: cr.Namespace}, &tls)
+			if apierrors.IsNotFound(err) {
+				r.logger.Debugf(ctx, "waiting for cert-manager to reissue certificate %#q", component)
+				continue
+			} else if err != nil {
+				return 0, 0, microerror.Mask(err)
+			}
+		}
+
+		if tls.CreationTimestamp.Before(started) {
+			err := r.deleteSecret(ctx, tls, component)
+			if err != nil {
+				return 0, 0, microerror.Mask(err)
+			}
+
+			continue
+		}
+
+		s, ok := secretsByComponent[component]
+		if !ok || !bytes.Equal(s.Data["crt"], tls.Data[corev1.TLSCertKey]) {
+			r.logger.Debugf(ctx, "waiting for reissued certificate %#q to be converted", component)
+			continue
+		}
+
+		reissued++
+	}
+
+	return reissued, len(certificates.Items), nil
+}
+
+func (r *Resource) deleteSecret(ctx context.Context, s corev1.Secret, component string) error {
+	r.logger.Debugf(ctx, "deleting secret %#q of certificate %#q issued before the rotation", fmt.Sprintf("%s/%s", s.Namespace, s.Name), component)
+
+	err := r.ctrlClient.Delete(ctx, &s)
+	if apierrors.IsNotFound(err) {
+		// fall through
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "deleted secret %#q of certificate %#q issued before the rotation", fmt.Sprintf("%s/%s", s.Namespace, s.Name), component)
+
+	return nil
+}
+
+// kubeConfigsRegenerated checks whether the kubeconfig Secrets of all
+// variants and their mirrors embed the reissued certificates and whether the
+// OIDC kubeconfig embeds the reissued CA.
+func (r *Resource) kubeConfigsRegenerated(ctx context.Context, cr apiv1beta1.Cluster, secretsByComponent map[string]corev1.Secret) (bool, error) {
+	variants := map[string]key.KubeConfigVariant{}
+	for _, v := range r.kubeConfigVariants {
+		variants[v.Name] = v
+
+		name := key.KubeConfigVariantSecretName(&cr, v)
+		namespace := key.KubeConfigVariantSecretNamespace(&cr, v)
+
+		var secret corev1.Secret
+		{
+			err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret)
+			if apierrors.IsNotFound(err) {
+				r.logger.Debugf(ctx, "waiting for kubeconfig secret %#q to be regenerated", fmt.Sprintf("%s/%s", namespace, name))
+				return false, nil
+			} else if err != nil {
+				return false, microerror.Mask(err)
+			}
+		}
+
+		if !embedsCertificate(secret.Data["kubeConfig"], secretsByComponent[key.KubeConfigVariantCertificate(v)], "crt") {
+			r.logger.Debugf(ctx, "waiting for kubeconfig secret %#q to be regenerated", fmt.Sprintf("%s/%s", namespace, name))
+			return false, nil
+		}
+	}
+
+	// Mirrors are found via their labels in all namespaces. Mirrors which do
+	// not exist yet are copied from the regenerated Secrets.
+	{
+		var mirrors corev1.SecretList
+		err := r.ctrlClient.List(
+			ctx,
+			&mirrors,
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr), label.KubeConfigMirror: "true"},
+		)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		for _, m := range mirrors.Items {
+			v, ok := variants[m.Labels[label.KubeConfigVariant]]
+			if !ok {
+				// Mirrors of removed variants are deleted by the kubeconfig
+				// resource.
+				continue
+			}
+
+			if !embedsCertificate(m.Data["kubeConfig"], secretsByComponent[key.KubeConfigVariantCertificate(v)], "crt") {
+				r.logger.Debugf(ctx, "waiting for kubeconfig secret mirror %#q to be regenerated", fmt.Sprintf("%s/%s", m.Namespace, m.Name))
+				return false, nil
+			}
+		}
+	}
+
+	// The OIDC kubeconfig only exists when enabled for the installation.
+	{
+		var configMap corev1.ConfigMap
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: key.OIDCKubeConfigConfigMapName(&cr), Namespace: key.ClusterID(&cr)}, &configMap)
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		} else if !embedsCertificate([]byte(configMap.Data["kubeConfig"]), secretsByComponent[certs.APICert.String()], "ca") {
+			r.logger.Debugf(ctx, "waiting for oidc kubeconfig config map %#q to be regenerated", fmt.Sprintf("%s/%s", configMap.Namespace, configMap.Name))
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// embedsCertificate checks whether the given kubeconfig embeds the given field
+// of the given certificate Secret.
+func embedsCertificate(kubeConfig []byte, cert corev1.Secret, field string) bool {
+	b, ok := cert.Data[field]
+	if !ok {
+		return false
+	}
+
+	return bytes.Contains(kubeConfig, []byte(base64.StdEncoding.EncodeToString(b)))
+}
+
+func (r *Resource) startRotation(ctx context.Context, cl apiv1beta1.Cluster, generation string) error {
+	r.logger.Debugf(ctx, "starting rotation of certificates for rotation generation %#q", generation)
+
+	// Fetch the latest version of the Cluster CR since the one we reconcile
+	// may already be outdated.
+	var cr apiv1beta1.Cluster
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if key.CertificatesRotationGeneration(&cr) != generation {
+		if cr.Annotations == nil {
+			cr.Annotations = map[string]string{}
+		}
+		cr.Annotations[annotation.CertificatesRotated] = generation
+
+		err := r.ctrlClient.Update(ctx, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	// The condition is removed first so that its last transition time marks
+	// the start of this rotation, even if the previous one never completed.
+	conditions.Delete(&cr, key.CertificatesRotatedCondition)
+	conditions.MarkFalse(&cr, key.CertificatesRotatedCondition, key.CertificatesRotationInProgressReason, apiv1beta1.ConditionSeverityInfo, "Rotation generation %s started.", generation)
+
+	err := r.ctrlClient.Status().Update(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.event.Emit(ctx, &cr, "CertificatesRotationStarted", fmt.Sprintf("started rotation of all certificates for rotation generation %s", generation))
+
+	r.logger.Debugf(ctx, "started rotation of certificates for rotation generation %#q", generation)
+
+	return nil
+}
+
+// updateCondition applies the given change to the CertificatesRotated
+// condition of the latest version of the Cluster CR and writes it in case it
+// changed.
+func (r *Resource) updateCondition(ctx context.Context, cl apiv1beta1.Cluster, change func(cr *apiv1beta1.Cluster)) error {
+	var cr apiv1beta1.Cluster
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var before apiv1beta1.Condition
+	if c := conditions.Get(&cr, key.CertificatesRotatedCondition); c != nil {
+		before = *c
+	}
+
+	change(&cr)
+
+	after := conditions.Get(&cr, key.CertificatesRotatedCondition)
+	if after.Status == before.Status && after.Reason == before.Reason && after.Message == before.Message {
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "updating condition %#q of cluster", key.CertificatesRotatedCondition)
+
+	err := r.ctrlClient.Status().Update(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "updated condition %#q of cluster", key.CertificatesRotatedCondition)
+
+	return nil
+}
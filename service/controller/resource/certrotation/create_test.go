@@ -0,0 +1,572 @@
+package certrotation
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_CertRotation_EnsureCreated(t *testing.T) {
+	started := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+	stale := metav1.NewTime(started.Add(-time.Hour))
+	fresh := metav1.NewTime(started.Add(time.Minute))
+
+	testCases := []struct {
+		name              string
+		clusterAnnotation map[string]string
+		condition         *apiv1beta1.Condition
+		certConfigGen     string
+		secretCreated     *metav1.Time
+		kubeConfigCrt     string
+
+		expectCanceled      bool
+		expectGeneration    string
+		expectStatus        corev1.ConditionStatus
+		expectSecretDeleted bool
+	}{
+		{
+			name: "case 0: no rotation requested",
+		},
+		{
+			name: "case 1: rotation gets started",
+			clusterAnnotation: map[string]string{
+				annotation.RotateCertificates: "1",
+			},
+			expectCanceled:   true,
+			expectGeneration: "1",
+			expectStatus:     corev1.ConditionFalse,
+		},
+		{
+			name: "case 2: certificate issued before the rotation is deleted",
+			clusterAnnotation: map[string]string{
+				annotation.RotateCertificates:  "1",
+				annotation.CertificatesRotated: "1",
+			},
+			condition:           newInProgressCondition(started),
+			certConfigGen:       "1",
+			secretCreated:       &stale,
+			expectGeneration:    "1",
+			expectStatus:        corev1.ConditionFalse,
+			expectSecretDeleted: true,
+		},
+		{
+			name: "case 3: waiting for CertConfig CR to be bumped",
+			clusterAnnotation: map[string]string{
+				annotation.RotateCertificates:  "2",
+				annotation.CertificatesRotated: "2",
+			},
+			condition:        newInProgressCondition(started),
+			certConfigGen:    "1",
+			secretCreated:    &stale,
+			expectGeneration: "2",
+			expectStatus:     corev1.ConditionFalse,
+		},
+		{
+			name: "case 4: waiting for kubeconfig",
+			clusterAnnotation: map[string]string{
+				annotation.RotateCertificates:  "1",
+				annotation.CertificatesRotated: "1",
+			},
+			condition:        newInProgressCondition(started),
+			certConfigGen:    "1",
+			secretCreated:    &fresh,
+			kubeConfigCrt:    "old",
+			expectGeneration: "1",
+			expectStatus:     corev1.ConditionFalse,
+		},
+		{
+			name: "case 5: rotation completed",
+			clusterAnnotation: map[string]string{
+				annotation.RotateCertificates:  "1",
+				annotation.CertificatesRotated: "1",
+			},
+			condition:        newInProgressCondition(started),
+			certConfigGen:    "1",
+			secretCreated:    &fresh,
+			kubeConfigCrt:    "new",
+			expectGeneration: "1",
+			expectStatus:     corev1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := reconciliationcanceledcontext.NewContext(context.Background(), make(chan struct{}))
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			cluster := newTestCluster(key.CertBackendCertConfig, tc.clusterAnnotation, tc.condition)
+			mustCreate(t, ctrlClient, cluster)
+
+			certConfig := &corev1alpha1.CertConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck-app-operator-api",
+					Namespace: "org-giantswarm",
+					Labels: map[string]string{
+						label.Cluster: "8y5ck",
+					},
+					Annotations: map[string]string{
+						annotation.RotationGeneration: tc.certConfigGen,
+					},
+				},
+				Spec: corev1alpha1.CertConfigSpec{
+					Cert: corev1alpha1.CertConfigSpecCert{
+						ClusterComponent: "app-operator-api",
+					},
+				},
+			}
+			mustCreate(t, ctrlClient, certConfig)
+
+			if tc.secretCreated != nil {
+				mustCreate(t, ctrlClient, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "8y5ck-app-operator-api",
+						Namespace:         "default",
+						CreationTimestamp: *tc.secretCreated,
+						Labels: map[string]string{
+							label.Certificate: "app-operator-api",
+							label.Cluster:     "8y5ck",
+						},
+					},
+					Data: map[string][]byte{
+						"crt": []byte("new"),
+					},
+				})
+			}
+
+			if tc.kubeConfigCrt != "" {
+				mustCreate(t, ctrlClient, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "8y5ck-kubeconfig",
+						Namespace: "8y5ck",
+					},
+					Data: map[string][]byte{
+						"kubeConfig": []byte("client-certificate-data: " + base64.StdEncoding.EncodeToString([]byte(tc.kubeConfigCrt))),
+					},
+				})
+			}
+
+			r := newTestResource(t, ctrlClient, key.DefaultKubeConfigVariants)
+
+			err := r.EnsureCreated(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if reconciliationcanceledcontext.IsCanceled(ctx) != tc.expectCanceled {
+				t.Fatalf("canceled == %t, want %t", reconciliationcanceledcontext.IsCanceled(ctx), tc.expectCanceled)
+			}
+
+			var updated apiv1beta1.Cluster
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: "8y5ck", Namespace: "org-giantswarm"}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if g := updated.Annotations[annotation.CertificatesRotated]; g != tc.expectGeneration {
+				t.Fatalf("generation == %#q, want %#q", g, tc.expectGeneration)
+			}
+
+			c := conditions.Get(&updated, "CertificatesRotated")
+			switch {
+			case tc.expectStatus == "" && c != nil:
+				t.Fatalf("condition == %#v, want nil", c)
+			case tc.expectStatus != "" && c == nil:
+				t.Fatalf("condition == nil, want status %#q", tc.expectStatus)
+			case c != nil && c.Status != tc.expectStatus:
+				t.Fatalf("condition status == %#q, want %#q", c.Status, tc.expectStatus)
+			}
+
+			if tc.secretCreated != nil {
+				err = ctrlClient.Get(ctx, types.NamespacedName{Name: "8y5ck-app-operator-api", Namespace: "default"}, &corev1.Secret{})
+				if apierrors.IsNotFound(err) != tc.expectSecretDeleted {
+					t.Fatalf("secret deleted == %t, want %t", apierrors.IsNotFound(err), tc.expectSecretDeleted)
+				}
+			}
+		})
+	}
+}
+
+func Test_CertRotation_EnsureCreated_CertManager(t *testing.T) {
+	started := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+	stale := metav1.NewTime(started.Add(-time.Hour))
+	fresh := metav1.NewTime(started.Add(time.Minute))
+
+	testCases := []struct {
+		name          string
+		certBackend   string
+		tlsCreated    *metav1.Time
+		certsCrt      string
+		kubeConfigCrt string
+
+		expectStatus     corev1.ConditionStatus
+		expectTLSDeleted bool
+	}{
+		{
+			name:         "case 0: waiting for certificate backend to be recorded",
+			certBackend:  "",
+			tlsCreated:   &fresh,
+			certsCrt:     "new",
+			expectStatus: corev1.ConditionFalse,
+		},
+		{
+			name:             "case 1: certificate issued before the rotation is deleted",
+			certBackend:      key.CertBackendCertManager,
+			tlsCreated:       &stale,
+			certsCrt:         "new",
+			kubeConfigCrt:    "new",
+			expectStatus:     corev1.ConditionFalse,
+			expectTLSDeleted: true,
+		},
+		{
+			name:          "case 2: waiting for reissued certificate to be converted",
+			certBackend:   key.CertBackendCertManager,
+			tlsCreated:    &fresh,
+			certsCrt:      "old",
+			kubeConfigCrt: "old",
+			expectStatus:  corev1.ConditionFalse,
+		},
+		{
+			name:          "case 3: rotation completed",
+			certBackend:   key.CertBackendCertManager,
+			tlsCreated:    &fresh,
+			certsCrt:      "new",
+			kubeConfigCrt: "new",
+			expectStatus:  corev1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := reconciliationcanceledcontext.NewContext(context.Background(), make(chan struct{}))
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			annotations := map[string]string{
+				annotation.RotateCertificates:  "1",
+				annotation.CertificatesRotated: "1",
+			}
+			cluster := newTestCluster(tc.certBackend, annotations, newInProgressCondition(started))
+			mustCreate(t, ctrlClient, cluster)
+
+			certificate := &unstructured.Unstructured{}
+			certificate.SetGroupVersionKind(schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"})
+			certificate.SetName("8y5ck-app-operator-api")
+			certificate.SetNamespace("org-giantswarm")
+			certificate.SetLabels(map[string]string{
+				label.Certificate: "app-operator-api",
+				label.Cluster:     "8y5ck",
+			})
+			mustCreate(t, ctrlClient, certificate)
+
+			mustCreate(t, ctrlClient, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "8y5ck-app-operator-api-tls",
+					Namespace:         "org-giantswarm",
+					CreationTimestamp: *tc.tlsCreated,
+				},
+				Data: map[string][]byte{
+					corev1.TLSCertKey: []byte("new"),
+				},
+			})
+
+			mustCreate(t, ctrlClient, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck-app-operator-api-certs",
+					Namespace: "org-giantswarm",
+					Labels: map[string]string{
+						label.Certificate: "app-operator-api",
+						label.Cluster:     "8y5ck",
+					},
+				},
+				Data: map[string][]byte{
+					"crt": []byte(tc.certsCrt),
+				},
+			})
+
+			if tc.kubeConfigCrt != "" {
+				mustCreate(t, ctrlClient, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "8y5ck-kubeconfig",
+						Namespace: "8y5ck",
+					},
+					Data: map[string][]byte{
+						"kubeConfig": []byte("client-certificate-data: " + base64.StdEncoding.EncodeToString([]byte(tc.kubeConfigCrt))),
+					},
+				})
+			}
+
+			r := newTestResource(t, ctrlClient, key.DefaultKubeConfigVariants)
+
+			err := r.EnsureCreated(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var updated apiv1beta1.Cluster
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: "8y5ck", Namespace: "org-giantswarm"}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := conditions.Get(&updated, "CertificatesRotated")
+			if c == nil {
+				t.Fatalf("condition == nil, want status %#q", tc.expectStatus)
+			}
+			if c.Status != tc.expectStatus {
+				t.Fatalf("condition status == %#q, want %#q", c.Status, tc.expectStatus)
+			}
+
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: "8y5ck-app-operator-api-tls", Namespace: "org-giantswarm"}, &corev1.Secret{})
+			if apierrors.IsNotFound(err) != tc.expectTLSDeleted {
+				t.Fatalf("secret deleted == %t, want %t", apierrors.IsNotFound(err), tc.expectTLSDeleted)
+			}
+		})
+	}
+}
+
+func Test_CertRotation_EnsureCreated_KubeConfigs(t *testing.T) {
+	started := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+	fresh := metav1.NewTime(started.Add(time.Minute))
+
+	variants := []key.KubeConfigVariant{
+		key.DefaultKubeConfigVariants[0],
+		{
+			Name:          "read-only",
+			Endpoint:      key.KubeConfigEndpointPublic,
+			Organizations: []string{"giantswarm:read-only"},
+			Secret: key.KubeConfigVariantSecret{
+				Name:      "kubeconfig-read-only",
+				Namespace: "monitoring",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		readOnlyCrt string
+		mirrorCrt   string
+		oidcCA      string
+
+		expectStatus corev1.ConditionStatus
+	}{
+		{
+			name:         "case 0: all kubeconfigs regenerated",
+			readOnlyCrt:  "new-read-only",
+			mirrorCrt:    "new",
+			oidcCA:       "new-ca",
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name:         "case 1: waiting for kubeconfig of another variant",
+			readOnlyCrt:  "old-read-only",
+			mirrorCrt:    "new",
+			oidcCA:       "new-ca",
+			expectStatus: corev1.ConditionFalse,
+		},
+		{
+			name:         "case 2: waiting for kubeconfig of another variant to be created",
+			readOnlyCrt:  "",
+			mirrorCrt:    "new",
+			oidcCA:       "new-ca",
+			expectStatus: corev1.ConditionFalse,
+		},
+		{
+			name:         "case 3: waiting for kubeconfig mirror",
+			readOnlyCrt:  "new-read-only",
+			mirrorCrt:    "old",
+			oidcCA:       "new-ca",
+			expectStatus: corev1.ConditionFalse,
+		},
+		{
+			name:         "case 4: waiting for oidc kubeconfig",
+			readOnlyCrt:  "new-read-only",
+			mirrorCrt:    "new",
+			oidcCA:       "old-ca",
+			expectStatus: corev1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := reconciliationcanceledcontext.NewContext(context.Background(), make(chan struct{}))
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			annotations := map[string]string{
+				annotation.RotateCertificates:  "1",
+				annotation.CertificatesRotated: "1",
+			}
+			cluster := newTestCluster(key.CertBackendCertConfig, annotations, newInProgressCondition(started))
+			mustCreate(t, ctrlClient, cluster)
+
+			certificates := map[string]map[string][]byte{
+				"api":                  {"ca": []byte("new-ca"), "crt": []byte("new-api")},
+				"app-operator-api":     {"ca": []byte("new-ca"), "crt": []byte("new")},
+				"kubeconfig-read-only": {"ca": []byte("new-ca"), "crt": []byte("new-read-only")},
+			}
+			for component, data := range certificates {
+				mustCreate(t, ctrlClient, &corev1alpha1.CertConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "8y5ck-" + component,
+						Namespace: "org-giantswarm",
+						Labels: map[string]string{
+							label.Cluster: "8y5ck",
+						},
+						Annotations: map[string]string{
+							annotation.RotationGeneration: "1",
+						},
+					},
+					Spec: corev1alpha1.CertConfigSpec{
+						Cert: corev1alpha1.CertConfigSpecCert{
+							ClusterComponent: component,
+						},
+					},
+				})
+				mustCreate(t, ctrlClient, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "8y5ck-" + component,
+						Namespace:         "default",
+						CreationTimestamp: fresh,
+						Labels: map[string]string{
+							label.Certificate: component,
+							label.Cluster:     "8y5ck",
+						},
+					},
+					Data: data,
+				})
+			}
+
+			mustCreate(t, ctrlClient, newTestKubeConfigSecret("8y5ck-kubeconfig", "8y5ck", "admin", false, "new"))
+			mustCreate(t, ctrlClient, newTestKubeConfigSecret("8y5ck-kubeconfig", "giantswarm", "admin", true, tc.mirrorCrt))
+			if tc.readOnlyCrt != "" {
+				mustCreate(t, ctrlClient, newTestKubeConfigSecret("8y5ck-kubeconfig-read-only", "monitoring", "read-only", false, tc.readOnlyCrt))
+			}
+			mustCreate(t, ctrlClient, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck-oidc-kubeconfig",
+					Namespace: "8y5ck",
+				},
+				Data: map[string]string{
+					"kubeConfig": "certificate-authority-data: " + base64.StdEncoding.EncodeToString([]byte(tc.oidcCA)),
+				},
+			})
+
+			r := newTestResource(t, ctrlClient, variants)
+
+			err := r.EnsureCreated(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var updated apiv1beta1.Cluster
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: "8y5ck", Namespace: "org-giantswarm"}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := conditions.Get(&updated, "CertificatesRotated")
+			if c == nil {
+				t.Fatalf("condition == nil, want status %#q", tc.expectStatus)
+			}
+			if c.Status != tc.expectStatus {
+				t.Fatalf("condition status == %#q, want %#q", c.Status, tc.expectStatus)
+			}
+		})
+	}
+}
+
+func mustCreate(t *testing.T, c client.Client, obj client.Object) {
+	err := c.Create(context.Background(), obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestCluster(certBackend string, annotations map[string]string, condition *apiv1beta1.Condition) *apiv1beta1.Cluster {
+	cluster := &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "8y5ck",
+			Namespace:   "org-giantswarm",
+			Annotations: map[string]string{},
+			Labels: map[string]string{
+				label.Cluster: "8y5ck",
+			},
+		},
+	}
+	for k, v := range annotations {
+		cluster.Annotations[k] = v
+	}
+	if certBackend != "" {
+		cluster.Annotations[annotation.CertBackend] = certBackend
+	}
+	if condition != nil {
+		cluster.Status.Conditions = apiv1beta1.Conditions{*condition}
+	}
+
+	return cluster
+}
+
+func newTestResource(t *testing.T, ctrlClient client.Client, variants []key.KubeConfigVariant) *Resource {
+	r, err := New(Config{
+		CtrlClient: ctrlClient,
+		Event:      recorder.New(recorder.Config{K8sClient: k8sclienttest.NewEmpty()}),
+		Logger:     microloggertest.New(),
+
+		KubeConfigVariants: variants,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func newTestKubeConfigSecret(name, namespace, variant string, mirror bool, crt string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				label.Cluster:           "8y5ck",
+				label.KubeConfigVariant: variant,
+			},
+		},
+		Data: map[string][]byte{
+			"kubeConfig": []byte("client-certificate-data: " + base64.StdEncoding.EncodeToString([]byte(crt))),
+		},
+	}
+	if mirror {
+		secret.Labels[label.KubeConfigMirror] = "true"
+	}
+
+	return secret
+}
+
+func newInProgressCondition(started metav1.Time) *apiv1beta1.Condition {
+	return &apiv1beta1.Condition{
+		Type:               "CertificatesRotated",
+		Status:             corev1.ConditionFalse,
+		Severity:           apiv1beta1.ConditionSeverityInfo,
+		Reason:             "RotationInProgress",
+		LastTransitionTime: started,
+	}
+}
@@ -0,0 +1,11 @@
+package certrotation
+
+import (
+	"context"
+)
+
+// EnsureDeleted is a no-op since all certificates are deleted together with
+// their CertConfig CRs by the certconfig resource.
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	return nil
+}
@@ -0,0 +1,67 @@
+package certrotation
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+)
+
+const (
+	Name = "certrotation"
+)
+
+type Config struct {
+	CtrlClient ctrlClient.Client
+	Event      recorder.Interface
+	Logger     micrologger.Logger
+
+	KubeConfigVariants []key.KubeConfigVariant
+}
+
+// Resource orchestrates the rotation of all certificates of a tenant cluster
+// once requested via annotation on the Cluster CR. With the certconfig backend
+// the certconfig resource bumps the rotation generation of all CertConfig CRs.
+// This resource replaces the certificate Secrets issued before the rotation
+// started, waits for cert-operator or cert-manager to reissue them and for all
+// kubeconfigs to be regenerated, and reports the progress in the
+// CertificatesRotated condition.
+type Resource struct {
+	ctrlClient ctrlClient.Client
+	event      recorder.Interface
+	logger     micrologger.Logger
+
+	kubeConfigVariants []key.KubeConfigVariant
+}
+
+func New(config Config) (*Resource, error) {
+	if config.CtrlClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
+	}
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if len(config.KubeConfigVariants) == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.KubeConfigVariants must not be empty", config)
+	}
+
+	r := &Resource{
+		ctrlClient: config.CtrlClient,
+		event:      config.Event,
+		logger:     config.Logger,
+
+		kubeConfigVariants: config.KubeConfigVariants,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
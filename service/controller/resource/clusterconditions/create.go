@@ -0,0 +1,432 @@
+package clusterconditions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/microerror"
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/hamaster"
+)
+
+const (
+	appStatusDeployed = "deployed"
+	appStatusFailed   = "failed"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cl, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Fetch the latest version of the Cluster CR since the one we reconcile
+	// may already be outdated.
+	var cr apiv1beta1.Cluster
+	{
+		err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	before := cr.GetConditions().DeepCopy()
+
+	computes := []func(context.Context, *apiv1beta1.Cluster) error{
+		r.computeCertificatesReady,
+		r.computeClusterValuesReady,
+		r.computeKubeconfigReady,
+		r.computeAppsReady,
+		r.computeNodesUpToDate,
+		r.computeAWSOperatorRoleARNValid,
+		r.computeCiliumENIOptionsSupported,
+		r.computeClusterAnnotationsValid,
+	}
+	for _, compute := range computes {
+		err = compute(ctx, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	conditions.SetSummary(&cr, conditions.WithConditions(key.ReadyConditions...))
+
+	if conditionsEqual(before, cr.GetConditions()) {
+		r.logger.Debugf(ctx, "conditions of cluster are up to date")
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "updating conditions of cluster")
+
+	err = r.ctrlClient.Status().Update(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "updated conditions of cluster")
+
+	return nil
+}
+
+func (r *Resource) computeCertificatesReady(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	specs, err := r.certSpec.Specs(ctx, *cr)
+	if hamaster.IsNotFound(err) {
+		conditions.MarkFalse(cr, key.CertificatesReadyCondition, key.CertificatesNotIssuedReason, apiv1beta1.ConditionSeverityInfo, "Control plane of the tenant cluster not found.")
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var secrets corev1.SecretList
+	{
+		err = r.ctrlClient.List(
+			ctx,
+			&secrets,
+			client.MatchingLabels{label.Cluster: key.ClusterID(cr)},
+			client.HasLabels{label.Certificate},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	issued := map[string]bool{}
+	for _, s := range secrets.Items {
+		issued[s.Labels[label.Certificate]] = true
+	}
+
+	var missing []string
+	for _, s := range specs {
+		if !issued[s.ClusterComponent] {
+			missing = append(missing, s.ClusterComponent)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		conditions.MarkFalse(cr, key.CertificatesReadyCondition, key.CertificatesNotIssuedReason, apiv1beta1.ConditionSeverityInfo, "Certificates %s not issued yet.", strings.Join(missing, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(cr, key.CertificatesReadyCondition)
+
+	return nil
+}
+
+func (r *Resource) computeClusterValuesReady(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	var missing []string
+	{
+		name := key.ClusterConfigMapName(cr)
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name, Namespace: key.ClusterID(cr)}, &corev1.ConfigMap{})
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, fmt.Sprintf("config map %s/%s", key.ClusterID(cr), name))
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+	{
+		name := key.ClusterSecretValuesName(cr)
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name, Namespace: key.ClusterID(cr)}, &corev1.Secret{})
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, fmt.Sprintf("secret %s/%s", key.ClusterID(cr), name))
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if len(missing) > 0 {
+		conditions.MarkFalse(cr, key.ClusterValuesReadyCondition, key.ClusterValuesNotFoundReason, apiv1beta1.ConditionSeverityInfo, "Cluster values %s not found.", strings.Join(missing, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(cr, key.ClusterValuesReadyCondition)
+
+	return nil
+}
+
+func (r *Resource) computeKubeconfigReady(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	var missing []string
+	for _, v := range r.variants {
+		name := key.KubeConfigVariantSecretName(cr, v)
+		namespace := key.KubeConfigVariantSecretNamespace(cr, v)
+
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &corev1.Secret{})
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, fmt.Sprintf("%s/%s", namespace, name))
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if len(missing) > 0 {
+		conditions.MarkFalse(cr, key.KubeconfigReadyCondition, key.KubeconfigNotFoundReason, apiv1beta1.ConditionSeverityInfo, "Kubeconfig secrets %s not found.", strings.Join(missing, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(cr, key.KubeconfigReadyCondition)
+
+	return nil
+}
+
+func (r *Resource) computeAppsReady(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	var apps v1alpha1.AppList
+	{
+		err := r.ctrlClient.List(
+			ctx,
+			&apps,
+			client.InNamespace(key.ClusterID(cr)),
+			client.MatchingLabels{
+				label.Cluster:   key.ClusterID(cr),
+				label.ManagedBy: project.Name(),
+			},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if len(apps.Items) == 0 {
+		conditions.MarkFalse(cr, key.AppsReadyCondition, key.AppsNotDeployedReason, apiv1beta1.ConditionSeverityInfo, "Apps not created yet.")
+		return nil
+	}
+
+	var failed, pending []string
+	for _, a := range apps.Items {
+		switch strings.ToLower(a.Status.Release.Status) {
+		case appStatusDeployed:
+			continue
+		case appStatusFailed:
+			failed = append(failed, a.Name)
+		default:
+			pending = append(pending, a.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		conditions.MarkFalse(cr, key.AppsReadyCondition, key.AppsNotDeployedReason, apiv1beta1.ConditionSeverityWarning, "Apps %s failed.", strings.Join(failed, ", "))
+		return nil
+	}
+	if len(pending) > 0 {
+		sort.Strings(pending)
+		conditions.MarkFalse(cr, key.AppsReadyCondition, key.AppsNotDeployedReason, apiv1beta1.ConditionSeverityInfo, "Apps %s not deployed yet.", strings.Join(pending, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(cr, key.AppsReadyCondition)
+
+	return nil
+}
+
+func (r *Resource) computeNodesUpToDate(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	var mds apiv1beta1.MachineDeploymentList
+	{
+		err := r.ctrlClient.List(
+			ctx,
+			&mds,
+			client.InNamespace(cr.Namespace),
+			client.MatchingLabels{label.Cluster: key.ClusterID(cr)},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var upgrading []string
+	for _, md := range mds.Items {
+		if !nodePoolUpToDate(md) {
+			upgrading = append(upgrading, md.Name)
+		}
+	}
+
+	if len(upgrading) > 0 {
+		sort.Strings(upgrading)
+		conditions.MarkFalse(cr, key.NodesUpToDateCondition, key.NodesUpgradingReason, apiv1beta1.ConditionSeverityInfo, "Nodes of node pools %s are not up to date.", strings.Join(upgrading, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(cr, key.NodesUpToDateCondition)
+
+	return nil
+}
+
+// nodePoolUpToDate checks whether all nodes of the node pool are ready and on
+// the desired version. The machinedeploymentstatus resource writes the node
+// counts of the tenant cluster into the status and the annotations of the
+// MachineDeployment CR. The desired number of replicas is only compared when
+// set, since node pools scaled by the cluster autoscaler do not define it.
+func nodePoolUpToDate(md apiv1beta1.MachineDeployment) bool {
+	s := md.Status
+	if s.ReadyReplicas != s.Replicas {
+		return false
+	}
+	if md.Spec.Replicas != nil && s.Replicas != *md.Spec.Replicas {
+		return false
+	}
+	if outdated, ok := md.Annotations[annotation.NodePoolOutdatedNodes]; ok && outdated != "0" {
+		return false
+	}
+
+	return true
+}
+
+func (r *Resource) computeAWSOperatorRoleARNValid(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	if !key.IsAWS(r.provider) {
+		return nil
+	}
+
+	var awsCluster infrastructurev1alpha3.AWSCluster
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, &awsCluster)
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var secret corev1.Secret
+	{
+		credentialSecret := awsCluster.Spec.Provider.CredentialSecret
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: credentialSecret.Name, Namespace: credentialSecret.Namespace}, &secret)
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	_, err := key.ParseARN(string(secret.Data[key.AWSOperatorRoleARNKey]))
+	if key.IsInvalidARN(err) {
+		conditions.MarkFalse(cr, key.AWSOperatorRoleARNValidCondition, key.InvalidARNReason, apiv1beta1.ConditionSeverityWarning, "Secret %s/%s: %s", secret.Namespace, secret.Name, microerror.Pretty(err, false))
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	conditions.MarkTrue(cr, key.AWSOperatorRoleARNValidCondition)
+
+	return nil
+}
+
+func (r *Resource) computeCiliumENIOptionsSupported(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	if !key.IsAWS(r.provider) || !key.CiliumEniModeEnabled(*cr) {
+		return nil
+	}
+
+	var ciliumVersion string
+	{
+		var re releasev1alpha1.Release
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: key.ReleaseName(key.ReleaseVersion(cr))}, &re)
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, c := range re.Spec.Components {
+			if c.Name == "cilium" {
+				ciliumVersion = c.Version
+			}
+		}
+	}
+
+	o, err := key.CiliumENIOptionsFromCluster(*cr)
+	if key.IsInvalidAnnotation(err) {
+		conditions.MarkFalse(cr, key.CiliumENIOptionsSupportedCondition, key.CiliumENIOptionsInvalidReason, apiv1beta1.ConditionSeverityWarning, "%s", microerror.Pretty(err, false))
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = key.ValidateCiliumENIOptions(o, ciliumVersion)
+	if key.IsUnsupportedCiliumENIOptions(err) {
+		conditions.MarkFalse(cr, key.CiliumENIOptionsSupportedCondition, key.CiliumENIOptionsUnsupportedReason, apiv1beta1.ConditionSeverityWarning, "%s", microerror.Pretty(err, false))
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	conditions.MarkTrue(cr, key.CiliumENIOptionsSupportedCondition)
+
+	return nil
+}
+
+// computeClusterAnnotationsValid reports annotations of the Cluster CR which
+// cannot be applied to the cluster values. The clusterconfigmap resource falls
+// back to the defaults for them, so that the cluster values are still written.
+// A warning event is emitted whenever the reported problems change.
+func (r *Resource) computeClusterAnnotationsValid(ctx context.Context, cr *apiv1beta1.Cluster) error {
+	validations := []func() error{
+		func() error {
+			_, err := key.IngressLoadBalancerFromCluster(cr, r.provider)
+			return err
+		},
+		func() error {
+			_, err := key.RegistryDomain(cr, "")
+			return err
+		},
+		func() error {
+			_, err := key.RegistryMirrors(cr, nil)
+			return err
+		},
+		func() error {
+			_, err := key.ExternalDNSZoneType(cr)
+			return err
+		},
+	}
+
+	var problems []string
+	for _, validate := range validations {
+		err := validate()
+		if key.IsInvalidAnnotation(err) {
+			problems = append(problems, microerror.Pretty(err, false))
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if len(problems) == 0 {
+		conditions.MarkTrue(cr, key.ClusterAnnotationsValidCondition)
+		return nil
+	}
+
+	message := strings.Join(problems, "; ")
+	if conditions.GetMessage(cr, key.ClusterAnnotationsValidCondition) != message {
+		r.event.Warn(ctx, cr, "InvalidAnnotation", fmt.Sprintf("falling back to defaults for invalid annotations: %s", message))
+	}
+	conditions.MarkFalse(cr, key.ClusterAnnotationsValidCondition, key.InvalidAnnotationReason, apiv1beta1.ConditionSeverityWarning, "%s", message)
+
+	return nil
+}
+
+// conditionsEqual compares conditions ignoring their transition times, which
+// only change together with the status anyway.
+func conditionsEqual(a, b apiv1beta1.Conditions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].Status != b[i].Status || a[i].Severity != b[i].Severity || a[i].Reason != b[i].Reason || a[i].Message != b[i].Message {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,562 @@
+package clusterconditions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
+	k8smetadataannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/micrologger/microloggertest"
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+type certSpec struct{}
+
+func (s certSpec) Specs(ctx context.Context, cr apiv1beta1.Cluster) ([]corev1alpha1.CertConfigSpecCert, error) {
+	specs := []corev1alpha1.CertConfigSpecCert{
+		{ClusterComponent: "api"},
+		{ClusterComponent: "etcd"},
+	}
+
+	return specs, nil
+}
+
+func Test_ClusterConditions_EnsureCreated(t *testing.T) {
+	testCases := []struct {
+		name    string
+		objects []client.Object
+
+		expectConditions map[apiv1beta1.ConditionType]string
+		expectReady      corev1.ConditionStatus
+	}{
+		{
+			name: "case 0: nothing created yet",
+			expectConditions: map[apiv1beta1.ConditionType]string{
+				key.CertificatesReadyCondition:  "api, etcd",
+				key.ClusterValuesReadyCondition: "8y5ck-cluster-values",
+				key.KubeconfigReadyCondition:    "8y5ck/8y5ck-kubeconfig",
+				key.AppsReadyCondition:          "not created",
+			},
+			expectReady: corev1.ConditionFalse,
+		},
+		{
+			name: "case 1: certificate missing, app failed and node pool upgrading",
+			objects: []client.Object{
+				newTestSecret("8y5ck-api", "org-giantswarm", map[string]string{label.Certificate: "api"}),
+				newTestApp("coredns", "deployed"),
+				newTestApp("cilium", "failed"),
+				newTestMachineDeployment("a1b2c", nil, 3, 3, "1"),
+			},
+			expectConditions: map[apiv1beta1.ConditionType]string{
+				key.CertificatesReadyCondition:  "etcd",
+				key.ClusterValuesReadyCondition: "8y5ck-cluster-secret-values",
+				key.KubeconfigReadyCondition:    "8y5ck/8y5ck-kubeconfig",
+				key.AppsReadyCondition:          "cilium failed",
+				key.NodesUpToDateCondition:      "a1b2c",
+			},
+			expectReady: corev1.ConditionFalse,
+		},
+		{
+			name: "case 2: everything ready",
+			objects: []client.Object{
+				newTestSecret("8y5ck-api", "org-giantswarm", map[string]string{label.Certificate: "api"}),
+				newTestSecret("8y5ck-etcd", "org-giantswarm", map[string]string{label.Certificate: "etcd"}),
+				newTestSecret("8y5ck-cluster-secret-values", "8y5ck", nil),
+				newTestSecret("8y5ck-kubeconfig", "8y5ck", nil),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "8y5ck-cluster-values",
+						Namespace: "8y5ck",
+					},
+				},
+				newTestApp("coredns", "Deployed"),
+				newTestMachineDeployment("a1b2c", nil, 3, 3, "0"),
+			},
+			expectReady: corev1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck",
+					Namespace: "org-giantswarm",
+					Labels: map[string]string{
+						label.Cluster: "8y5ck",
+					},
+				},
+			}
+			err := ctrlClient.Create(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, o := range tc.objects {
+				err = ctrlClient.Create(ctx, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var r *Resource
+			{
+				c := Config{
+					CertSpec:   certSpec{},
+					CtrlClient: ctrlClient,
+					Event:      recorder.New(recorder.Config{K8sClient: k8sclienttest.NewEmpty()}),
+					Logger:     microloggertest.New(),
+
+					Provider: label.ProviderAWS,
+					Variants: key.DefaultKubeConfigVariants,
+				}
+
+				r, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = r.EnsureCreated(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var updated apiv1beta1.Cluster
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: "8y5ck", Namespace: "org-giantswarm"}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, ct := range key.ReadyConditions {
+				c := conditions.Get(&updated, ct)
+				if c == nil {
+					t.Fatalf("condition %#q == nil, want non-nil", ct)
+				}
+
+				message, ok := tc.expectConditions[ct]
+				if !ok {
+					if c.Status != corev1.ConditionTrue {
+						t.Fatalf("condition %#q status == %#q, want %#q", ct, c.Status, corev1.ConditionTrue)
+					}
+					continue
+				}
+				if c.Status != corev1.ConditionFalse {
+					t.Fatalf("condition %#q status == %#q, want %#q", ct, c.Status, corev1.ConditionFalse)
+				}
+				if !strings.Contains(c.Message, message) {
+					t.Fatalf("condition %#q message == %#q, want containing %#q", ct, c.Message, message)
+				}
+			}
+
+			ready := conditions.Get(&updated, apiv1beta1.ReadyCondition)
+			if ready == nil || ready.Status != tc.expectReady {
+				t.Fatalf("condition %#q == %#v, want status %#q", apiv1beta1.ReadyCondition, ready, tc.expectReady)
+			}
+		})
+	}
+}
+
+func Test_ClusterConditions_AWSOperatorRoleARNValid(t *testing.T) {
+	testCases := []struct {
+		name string
+		arn  string
+
+		expectStatus  corev1.ConditionStatus
+		expectMessage string
+	}{
+		{
+			name:         "case 0: valid ARN",
+			arn:          "arn:aws-cn:iam::123456789012:role/GiantSwarmAWSOperator",
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name:          "case 1: unknown partition",
+			arn:           "arn:aws-foo:iam::123456789012:role/GiantSwarmAWSOperator",
+			expectStatus:  corev1.ConditionFalse,
+			expectMessage: "unknown partition",
+		},
+		{
+			name:          "case 2: missing account ID",
+			arn:           "arn:aws:iam:::role/GiantSwarmAWSOperator",
+			expectStatus:  corev1.ConditionFalse,
+			expectMessage: "12 digit account ID",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			awsCluster := &infrastructurev1alpha3.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck",
+					Namespace: "org-giantswarm",
+				},
+				Spec: infrastructurev1alpha3.AWSClusterSpec{
+					Provider: infrastructurev1alpha3.AWSClusterSpecProvider{
+						CredentialSecret: infrastructurev1alpha3.AWSClusterSpecProviderCredentialSecret{
+							Name:      "credential-default",
+							Namespace: "giantswarm",
+						},
+					},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "credential-default",
+					Namespace: "giantswarm",
+				},
+				Data: map[string][]byte{
+					key.AWSOperatorRoleARNKey: []byte(tc.arn),
+				},
+			}
+			for _, o := range []client.Object{awsCluster, secret} {
+				err := ctrlClient.Create(ctx, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r := &Resource{
+				ctrlClient: ctrlClient,
+				logger:     microloggertest.New(),
+				provider:   label.ProviderAWS,
+			}
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck",
+					Namespace: "org-giantswarm",
+				},
+			}
+
+			err := r.computeAWSOperatorRoleARNValid(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := conditions.Get(cluster, key.AWSOperatorRoleARNValidCondition)
+			if c == nil || c.Status != tc.expectStatus {
+				t.Fatalf("condition %#q == %#v, want status %#q", key.AWSOperatorRoleARNValidCondition, c, tc.expectStatus)
+			}
+			if !strings.Contains(c.Message, tc.expectMessage) {
+				t.Fatalf("condition %#q message == %#q, want containing %#q", key.AWSOperatorRoleARNValidCondition, c.Message, tc.expectMessage)
+			}
+		})
+	}
+}
+
+func Test_ClusterConditions_CiliumENIOptionsSupported(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+
+		expectStatus corev1.ConditionStatus
+		expectReason string
+	}{
+		{
+			name:         "case 0: default options",
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "case 1: invalid pre-allocation",
+			annotations: map[string]string{
+				annotation.CiliumENIPreAllocate: "many",
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: key.CiliumENIOptionsInvalidReason,
+		},
+		{
+			name: "case 2: prefix delegation not supported by the release",
+			annotations: map[string]string{
+				annotation.CiliumENIPrefixDelegation: "true",
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: key.CiliumENIOptionsUnsupportedReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			release := &releasev1alpha1.Release{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "v18.0.0",
+				},
+				Spec: releasev1alpha1.ReleaseSpec{
+					Components: []releasev1alpha1.ReleaseSpecComponent{
+						{Name: "cilium", Version: "1.12.9"},
+					},
+				},
+			}
+			err := ctrlClient.Create(ctx, release)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			annotations := map[string]string{
+				k8smetadataannotation.CiliumIpamModeAnnotation: k8smetadataannotation.CiliumIpamModeENI,
+			}
+			for k, v := range tc.annotations {
+				annotations[k] = v
+			}
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "8y5ck",
+					Namespace:   "org-giantswarm",
+					Annotations: annotations,
+					Labels: map[string]string{
+						label.ReleaseVersion: "18.0.0",
+					},
+				},
+			}
+
+			r := &Resource{
+				ctrlClient: ctrlClient,
+				logger:     microloggertest.New(),
+				provider:   label.ProviderAWS,
+			}
+
+			err = r.computeCiliumENIOptionsSupported(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := conditions.Get(cluster, key.CiliumENIOptionsSupportedCondition)
+			if c == nil || c.Status != tc.expectStatus || c.Reason != tc.expectReason {
+				t.Fatalf("condition %#q == %#v, want status %#q and reason %#q", key.CiliumENIOptionsSupportedCondition, c, tc.expectStatus, tc.expectReason)
+			}
+		})
+	}
+}
+
+func Test_ClusterConditions_ClusterAnnotationsValid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		reported    bool
+
+		expectStatus corev1.ConditionStatus
+		expectEvents int
+	}{
+		{
+			name:         "case 0: valid annotations",
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "case 1: invalid ingress load balancer",
+			annotations: map[string]string{
+				annotation.IngressLoadBalancerScheme: "private",
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectEvents: 1,
+		},
+		{
+			name: "case 2: invalid registry domain and mirrors",
+			annotations: map[string]string{
+				annotation.RegistryDomain:  "https://gsoci.azurecr.io",
+				annotation.RegistryMirrors: "mirror.gcr.io,https://mirror.gcr.io",
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectEvents: 1,
+		},
+		{
+			name: "case 3: invalid external-dns zone type",
+			annotations: map[string]string{
+				annotation.ExternalDNSZoneType: "internal",
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectEvents: 1,
+		},
+		{
+			name: "case 4: already reported invalid ingress load balancer",
+			annotations: map[string]string{
+				annotation.IngressLoadBalancerScheme: "private",
+			},
+			reported:     true,
+			expectStatus: corev1.ConditionFalse,
+			expectEvents: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "8y5ck",
+					Namespace:   "org-giantswarm",
+					Annotations: tc.annotations,
+				},
+			}
+
+			event := &fakeRecorder{}
+			r := &Resource{
+				event:    event,
+				logger:   microloggertest.New(),
+				provider: label.ProviderAWS,
+			}
+
+			if tc.reported {
+				err := r.computeClusterAnnotationsValid(context.Background(), cluster)
+				if err != nil {
+					t.Fatal(err)
+				}
+				event.warnings = 0
+			}
+
+			err := r.computeClusterAnnotationsValid(context.Background(), cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := conditions.Get(cluster, key.ClusterAnnotationsValidCondition)
+			if c == nil || c.Status != tc.expectStatus {
+				t.Fatalf("condition %#q == %#v, want status %#q", key.ClusterAnnotationsValidCondition, c, tc.expectStatus)
+			}
+			if tc.expectStatus == corev1.ConditionFalse && c.Reason != key.InvalidAnnotationReason {
+				t.Fatalf("reason == %#q, want %#q", c.Reason, key.InvalidAnnotationReason)
+			}
+			if event.warnings != tc.expectEvents {
+				t.Fatalf("warning events == %d, want %d", event.warnings, tc.expectEvents)
+			}
+		})
+	}
+}
+
+type fakeRecorder struct {
+	warnings int
+}
+
+func (r *fakeRecorder) Emit(ctx context.Context, obj runtime.Object, reason, message string) {}
+
+func (r *fakeRecorder) Warn(ctx context.Context, obj runtime.Object, reason, message string) {
+	r.warnings++
+}
+
+func Test_nodePoolUpToDate(t *testing.T) {
+	three := int32(3)
+
+	testCases := []struct {
+		name     string
+		md       *apiv1beta1.MachineDeployment
+		expected bool
+	}{
+		{
+			name:     "case 0: all nodes ready without desired replicas",
+			md:       newTestMachineDeployment("a1b2c", nil, 3, 3, ""),
+			expected: true,
+		},
+		{
+			name:     "case 1: all nodes ready and upgraded",
+			md:       newTestMachineDeployment("a1b2c", &three, 3, 3, "0"),
+			expected: true,
+		},
+		{
+			name:     "case 2: node not ready",
+			md:       newTestMachineDeployment("a1b2c", nil, 3, 2, "0"),
+			expected: false,
+		},
+		{
+			name:     "case 3: desired replicas not reached",
+			md:       newTestMachineDeployment("a1b2c", &three, 2, 2, "0"),
+			expected: false,
+		},
+		{
+			name:     "case 4: node on outdated version",
+			md:       newTestMachineDeployment("a1b2c", &three, 3, 3, "1"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := nodePoolUpToDate(*tc.md)
+			if actual != tc.expected {
+				t.Fatalf("up to date == %t, want %t", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func newTestApp(name, status string) *v1alpha1.App {
+	return &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "8y5ck",
+			Labels: map[string]string{
+				label.Cluster:   "8y5ck",
+				label.ManagedBy: project.Name(),
+			},
+		},
+		Status: v1alpha1.AppStatus{
+			Release: v1alpha1.AppStatusRelease{
+				Status: status,
+			},
+		},
+	}
+}
+
+func newTestMachineDeployment(name string, desired *int32, replicas, ready int32, outdated string) *apiv1beta1.MachineDeployment {
+	md := &apiv1beta1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "org-giantswarm",
+			Labels: map[string]string{
+				label.Cluster: "8y5ck",
+			},
+		},
+		Spec: apiv1beta1.MachineDeploymentSpec{
+			Replicas: desired,
+		},
+		Status: apiv1beta1.MachineDeploymentStatus{
+			Replicas:      replicas,
+			ReadyReplicas: ready,
+		},
+	}
+	if outdated != "" {
+		md.Annotations = map[string]string{
+			annotation.NodePoolOutdatedNodes: outdated,
+		}
+	}
+
+	return md
+}
+
+func newTestSecret(name, namespace string, labels map[string]string) *corev1.Secret {
+	if labels != nil {
+		labels[label.Cluster] = "8y5ck"
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+}
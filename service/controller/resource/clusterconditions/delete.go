@@ -0,0 +1,11 @@
+package clusterconditions
+
+import (
+	"context"
+)
+
+// EnsureDeleted is a no-op since the conditions are removed together with the
+// Cluster CR.
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	return nil
+}
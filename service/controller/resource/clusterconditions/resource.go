@@ -0,0 +1,79 @@
+package clusterconditions
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/certspec"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+)
+
+const (
+	Name = "clusterconditions"
+)
+
+type Config struct {
+	CertSpec   certspec.Interface
+	CtrlClient ctrlClient.Client
+	Event      recorder.Interface
+	Logger     micrologger.Logger
+
+	Provider string
+	Variants []key.KubeConfigVariant
+}
+
+// Resource maintains CAPI conditions on the Cluster CR for all providers. It
+// reports whether certificates, cluster values, kubeconfigs, apps and nodes
+// of the tenant cluster are ready and summarizes them in the Ready condition.
+// Invalid annotations configuring the cluster values are reported in the
+// ClusterAnnotationsValid condition together with a warning event. On AWS it additionally reports whether the aws-operator role ARN is valid and
+// whether the Cilium ENI options are supported by the release.
+type Resource struct {
+	certSpec   certspec.Interface
+	ctrlClient ctrlClient.Client
+	event      recorder.Interface
+	logger     micrologger.Logger
+
+	provider string
+	variants []key.KubeConfigVariant
+}
+
+func New(config Config) (*Resource, error) {
+	if config.CertSpec == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CertSpec must not be empty", config)
+	}
+	if config.CtrlClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
+	}
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.Provider == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
+	}
+	if len(config.Variants) == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Variants must not be empty", config)
+	}
+
+	r := &Resource{
+		certSpec:   config.CertSpec,
+		ctrlClient: config.CtrlClient,
+		event:      config.Event,
+		logger:     config.Logger,
+
+		provider: config.Provider,
+		variants: config.Variants,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
@@ -0,0 +1,35 @@
+package clusterconfigmap
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+// ciliumENIOptions returns the Cilium ENI options configured via annotations
+// of the given Cluster CR. In case they are invalid or not supported by the
+// release, the default options are returned so that the cilium values stay
+// functional. The problem is reported in the CiliumENIOptionsSupported
+// condition of the Cluster CR by the clusterconditions resource.
+func (r *Resource) ciliumENIOptions(ctx context.Context, cr apiv1beta1.Cluster, ciliumVersion string) (key.CiliumENIOptions, error) {
+	o, err := key.CiliumENIOptionsFromCluster(cr)
+	if key.IsInvalidAnnotation(err) {
+		r.logger.Debugf(ctx, "falling back to default cilium eni options: %s", microerror.Pretty(err, false))
+		return key.DefaultCiliumENIOptions(), nil
+	} else if err != nil {
+		return key.CiliumENIOptions{}, microerror.Mask(err)
+	}
+
+	err = key.ValidateCiliumENIOptions(o, ciliumVersion)
+	if key.IsUnsupportedCiliumENIOptions(err) {
+		r.logger.Debugf(ctx, "falling back to default cilium eni options: %s", microerror.Pretty(err, false))
+		return key.DefaultCiliumENIOptions(), nil
+	} else if err != nil {
+		return key.CiliumENIOptions{}, microerror.Mask(err)
+	}
+
+	return o, nil
+}
@@ -22,6 +22,8 @@ func (r *Resource) GetCurrentState(ctx context.Context, obj interface{}) ([]*cor
 
 	// The config maps are deleted when the namespace is deleted.
 	if key.IsDeleted(&cr) {
+		r.forgetDenials(cr)
+
 		r.logger.Debugf(ctx, "not deleting config maps for tenant cluster %#q", key.ClusterID(&cr))
 		r.logger.Debugf(ctx, "canceling resource")
 		resourcecanceledcontext.SetCanceled(ctx)
@@ -32,8 +34,10 @@ func (r *Resource) GetCurrentState(ctx context.Context, obj interface{}) ([]*cor
 	{
 		r.logger.Debugf(ctx, "finding cluster config maps in namespace %#q", key.ClusterID(&cr))
 
+		// Kubeconfig config maps live in the same namespace but are managed by
+		// the oidckubeconfig resource.
 		lo := metav1.ListOptions{
-			LabelSelector: fmt.Sprintf("%s=%s", label.ManagedBy, project.Name()),
+			LabelSelector: fmt.Sprintf("%s=%s,%s!=%s", label.ManagedBy, project.Name(), label.ConfigMapType, label.ConfigMapTypeKubeConfig),
 		}
 
 		list, err := r.k8sClient.CoreV1().ConfigMaps(key.ClusterID(&cr)).List(ctx, lo)
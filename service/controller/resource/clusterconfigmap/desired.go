@@ -3,24 +3,27 @@ package clusterconfigmap
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strconv"
 
 	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
 
 	"github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	providerv1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
 	"github.com/giantswarm/cluster-operator/v5/pkg/label"
 	"github.com/giantswarm/cluster-operator/v5/pkg/project"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/proxy"
 )
 
 func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*corev1.ConfigMap, error) {
@@ -28,6 +31,71 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
+
+	specs, err := r.cachedSpecs(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var configMaps []*corev1.ConfigMap
+
+	for _, spec := range specs {
+		configMap, err := newConfigMap(cr, spec)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		configMaps = append(configMaps, configMap)
+	}
+
+	return configMaps, nil
+}
+
+// cachedSpecs returns the specs computed by desiredSpecs once per
+// reconciliation, since the config map resource and the secret state getter
+// are both based on them. The cancellation of the resource is cached as well,
+// so that the secret state getter does not delete all Secrets when the specs
+// could not be computed.
+func (r *Resource) cachedSpecs(ctx context.Context, cr apiv1beta1.Cluster) ([]configMapSpec, error) {
+	ck := r.specsCache.Key(ctx, &cr)
+	if ck == "" {
+		specs, err := r.desiredSpecs(ctx, cr)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		return specs, nil
+	}
+
+	val, ok := r.specsCache.Get(ctx, ck)
+	if ok {
+		s := val.(specsEntry)
+		if s.Canceled {
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+		}
+
+		return s.Specs, nil
+	}
+
+	specs, err := r.desiredSpecs(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	r.specsCache.Set(ctx, ck, specsEntry{
+		Canceled: resourcecanceledcontext.IsCanceled(ctx),
+		Specs:    specs,
+	})
+
+	return specs, nil
+}
+
+// desiredSpecs computes the values of all config maps managed by this
+// resource. Sensitive values like account identifiers are not part of the
+// public values but of the secret values, which are managed in Secrets next
+// to the config maps by the secret state getter of this package.
+func (r *Resource) desiredSpecs(ctx context.Context, cr apiv1beta1.Cluster) ([]configMapSpec, error) {
 	bd, err := r.baseDomain.BaseDomain(ctx, &cr)
 	if err != nil {
 		return nil, microerror.Mask(err)
@@ -55,17 +123,50 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 		}
 	}
 
-	// useProxyProtocol is only enabled by default for AWS clusters.
-	var useProxyProtocol bool
 	// enableCiliumNetworkPolicy is only enabled by default for AWS clusters.
 	var enableCiliumNetworkPolicy bool
 	{
 		if key.IsAWS(r.provider) {
-			useProxyProtocol = true
 			enableCiliumNetworkPolicy = true
 		}
 	}
 
+	var ingressValues map[string]interface{}
+	{
+		lb, err := key.IngressLoadBalancerFromCluster(&cr, r.provider)
+		if key.IsInvalidAnnotation(err) {
+			// The invalid annotation is reported in the
+			// ClusterAnnotationsValid condition of the Cluster CR by the
+			// clusterconditions resource. The provider default keeps the
+			// cluster values of all other apps up to date meanwhile.
+			r.logger.Debugf(ctx, "falling back to default ingress load balancer: %s", microerror.Pretty(err, false))
+			lb = key.DefaultIngressLoadBalancer(r.provider)
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		ingressValues = map[string]interface{}{
+			"baseDomain": key.TenantEndpoint(&cr, bd),
+			"clusterID":  key.ClusterID(&cr),
+			"configmap": map[string]interface{}{
+				"use-proxy-protocol": strconv.FormatBool(lb.ProxyProtocol),
+			},
+		}
+
+		serviceValues := map[string]interface{}{}
+		if annotations := lb.ServiceAnnotations(r.provider); len(annotations) > 0 {
+			serviceValues["annotations"] = annotations
+		}
+		if len(lb.SourceRanges) > 0 {
+			serviceValues["loadBalancerSourceRanges"] = lb.SourceRanges
+		}
+		if len(serviceValues) > 0 {
+			ingressValues["controller"] = map[string]interface{}{
+				"service": serviceValues,
+			}
+		}
+	}
+
 	var pssEnforced bool
 	{
 		pssEnforced, err = key.IsPSSRelease(&cr)
@@ -74,6 +175,36 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 		}
 	}
 
+	var registryDomain string
+	var registryMirrors []string
+	{
+		// Invalid registry annotations are reported in the
+		// ClusterAnnotationsValid condition of the Cluster CR and fall back to
+		// the installation defaults.
+		registryDomain, err = key.RegistryDomain(&cr, r.registryDomain)
+		if key.IsInvalidAnnotation(err) {
+			r.logger.Debugf(ctx, "falling back to default registry domain: %s", microerror.Pretty(err, false))
+			registryDomain = r.registryDomain
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		registryMirrors, err = key.RegistryMirrors(&cr, r.registryMirrors)
+		if key.IsInvalidAnnotation(err) {
+			r.logger.Debugf(ctx, "falling back to default registry mirrors: %s", microerror.Pretty(err, false))
+			registryMirrors = r.registryMirrors
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var proxySettings proxy.Settings
+	{
+		proxySettings, err = r.proxy.Settings(ctx, &cr)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	values := map[string]interface{}{
 		"baseDomain": key.TenantEndpoint(&cr, bd),
 		"bootstrapMode": map[string]interface{}{
@@ -92,19 +223,57 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 				},
 			},
 		},
-		"clusterCA":    clusterCA,
 		"clusterDNSIP": r.dnsIP,
 		"clusterID":    key.ClusterID(&cr),
 		"ciliumNetworkPolicy": map[string]interface{}{
 			"enabled": enableCiliumNetworkPolicy,
 		},
 		"global": map[string]interface{}{
+			"image": map[string]interface{}{
+				"mirrors":  registryMirrors,
+				"registry": registryDomain,
+			},
 			"podSecurityStandards": map[string]interface{}{
 				"enforced": pssEnforced,
 			},
 		},
 	}
 
+	// Apps like logging and monitoring agents label their data with the
+	// installation they run in.
+	managementClusterValues := map[string]interface{}{
+		"name":     r.installation,
+		"provider": r.provider,
+	}
+	if r.installationAPIEndpoint != "" {
+		managementClusterValues["apiEndpoint"] = r.installationAPIEndpoint
+	}
+	if r.installationRegion != "" {
+		managementClusterValues["region"] = r.installationRegion
+	}
+	values["managementCluster"] = managementClusterValues
+
+	secretValues := map[string]interface{}{
+		"clusterCA": clusterCA,
+	}
+
+	// The proxy URLs may contain credentials, which is why the ConfigMap only
+	// gets the redacted settings. The complete settings go into the Secret,
+	// which App CRs reference next to the ConfigMap and which takes
+	// precedence when app-operator merges both.
+	if proxySettings.Enabled() {
+		values["proxy"] = proxySettings.Redacted().Values()
+		secretValues["proxy"] = proxySettings.Values()
+	}
+
+	{
+		err = r.applyValuesOverrides(ctx, cr, values)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	externalDnsSecretValues := map[string]interface{}{}
 	externalDnsValues := map[string]interface{}{
 		"txtOwnerId":       "giantswarm-io-external-dns",
 		"txtPrefix":        key.ClusterID(&cr),
@@ -117,6 +286,7 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 	if key.IsAWS(r.provider) {
 		var irsa bool
 		var accountID string
+		var partition string
 		var vpcID string
 
 		awsCluster := &v1alpha3.AWSCluster{}
@@ -131,46 +301,133 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 
 		secret, err := r.k8sClient.CoreV1().Secrets(awsCluster.Spec.Provider.CredentialSecret.Namespace).Get(ctx, awsCluster.Spec.Provider.CredentialSecret.Name, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) {
-			r.logger.Debugf(ctx, "secret '%s/%s' not found cannot set accountID", cr.Namespace, key.APISecretName(&cr))
+			// Returning no specs would make the desired state empty and
+			// delete all config maps, so we wait for the secret instead.
+			r.logger.Debugf(ctx, "secret '%s/%s' not found cannot set accountID", awsCluster.Spec.Provider.CredentialSecret.Namespace, awsCluster.Spec.Provider.CredentialSecret.Name)
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
 			return nil, nil
 		} else if err != nil {
 			return nil, microerror.Mask(err)
 		}
-		arn := string(secret.Data["aws.awsoperator.arn"])
-		if arn == "" {
-			return nil, microerror.Mask(fmt.Errorf("Unable to find ARN from secret %s/%s", secret.Namespace, secret.Name))
+		arn, err := key.ParseARN(string(secret.Data[key.AWSOperatorRoleARNKey]))
+		if key.IsInvalidARN(err) {
+			// The malformed ARN is reported in the AWSOperatorRoleARNValid
+			// condition of the Cluster CR by the clusterconditions resource.
+			// Values derived from the ARN are left out until it is fixed.
+			r.logger.Debugf(ctx, "not setting values derived from the aws-operator role ARN of secret '%s/%s': %s", secret.Namespace, secret.Name, microerror.Pretty(err, false))
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			accountID = arn.AccountID
+			partition = arn.Partition
 		}
 
-		re := regexp.MustCompile(`[-]?\d[\d,]*[\.]?[\d{2}]*`)
-		accountID = re.FindAllString(arn, 1)[0]
-
 		vpcID = awsCluster.Status.Provider.Network.VPCID
 
-		values["aws"] = map[string]interface{}{
-			"accountID": accountID,
-			"irsa":      strconv.FormatBool(irsa),
-			"region":    awsCluster.Spec.Provider.Region,
-			"vpcID":     vpcID,
+		awsValues := map[string]interface{}{
+			"irsa":   strconv.FormatBool(irsa),
+			"region": awsCluster.Spec.Provider.Region,
+		}
+		if partition != "" {
+			awsValues["partition"] = partition
+		}
+		values["aws"] = awsValues
+
+		awsSecretValues := map[string]interface{}{
+			"vpcID": vpcID,
+		}
+		if accountID != "" {
+			awsSecretValues["accountID"] = accountID
+		}
+		secretValues["aws"] = awsSecretValues
+
+		zoneType, err := key.ExternalDNSZoneType(&cr)
+		if key.IsInvalidAnnotation(err) {
+			// The invalid annotation is reported in the
+			// ClusterAnnotationsValid condition of the Cluster CR. Restricting
+			// external-dns to public hosted zones keeps it away from private
+			// ones it was not explicitly given.
+			r.logger.Debugf(ctx, "falling back to %#q external-dns zone type: %s", key.ExternalDNSZoneTypePublic, microerror.Pretty(err, false))
+			zoneType = key.ExternalDNSZoneTypePublic
+		} else if err != nil {
+			return nil, microerror.Mask(err)
 		}
 
-		externalDnsValues["extraArgs"] = []string{
+		extraArgs := []string{
 			"--aws-batch-change-interval=10s",
 		}
+		// Private hosted zones are e.g. used by clusters which are not
+		// reachable from the internet.
+		if zoneType != "" {
+			extraArgs = append(extraArgs, fmt.Sprintf("--aws-zone-type=%s", zoneType))
+		}
+
+		externalDnsValues["extraArgs"] = extraArgs
 		externalDnsValues["aws"] = map[string]interface{}{
 			"batchChangeInterval": nil,
 		}
-		externalDnsValues["domainFilters"] = []string{
+		externalDnsValues["domainFilters"] = append([]string{
 			key.TenantEndpoint(&cr, bd),
-		}
-		if !key.IsAWSChina(awsCluster.Spec.Provider.Region) {
-			externalDnsValues["serviceAccount"] = map[string]interface{}{
+		}, key.ExternalDNSDomainFilters(&cr)...)
+		if accountID != "" {
+			externalDnsSecretValues["serviceAccount"] = map[string]interface{}{
 				"annotations": map[string]interface{}{
-					"eks.amazonaws.com/role-arn": fmt.Sprintf("arn:aws:iam::%s:role/%s-Route53Manager-Role", accountID, key.ClusterID(&cr)),
+					"eks.amazonaws.com/role-arn": key.Route53ManagerRoleARN(&cr, partition, accountID),
 				},
 			}
 		}
 	}
 
+	if key.IsAzure(r.provider) {
+		var list providerv1alpha1.AzureConfigList
+		err := r.ctrlClient.List(
+			ctx,
+			&list,
+			client.InNamespace(metav1.NamespaceDefault),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr)},
+		)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		if len(list.Items) != 1 {
+			return nil, microerror.Maskf(notFoundError, "expected 1 AzureConfig CR for cluster %#q, got %d", key.ClusterID(&cr), len(list.Items))
+		}
+		azureConfig := list.Items[0]
+
+		credentialSecret := azureConfig.Spec.Azure.CredentialSecret
+		secret, err := r.k8sClient.CoreV1().Secrets(credentialSecret.Namespace).Get(ctx, credentialSecret.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// Returning no specs would make the desired state empty and
+			// delete all config maps, so we wait for the secret instead.
+			r.logger.Debugf(ctx, "secret '%s/%s' not found cannot set azure subscription", credentialSecret.Namespace, credentialSecret.Name)
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+			return nil, nil
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		// The DNS zone of the tenant cluster is the same basedomain uses, so
+		// external-dns manages records in the zone the tenant endpoint lives
+		// in. Authentication happens via the managed identity of the nodes,
+		// so besides the subscription and tenant identifiers no client
+		// secrets are needed. The identifiers are still kept out of the
+		// public values like the AWS account ID.
+		externalDnsValues["provider"] = "azure"
+		externalDnsValues["azure"] = map[string]interface{}{
+			"resourceGroup":               azureConfig.Spec.Azure.DNSZones.API.ResourceGroup,
+			"useManagedIdentityExtension": true,
+		}
+		externalDnsSecretValues["azure"] = map[string]interface{}{
+			"subscriptionId": string(secret.Data["azure.azureoperator.subscriptionid"]),
+			"tenantId":       string(secret.Data["azure.azureoperator.tenantid"]),
+		}
+		externalDnsValues["domainFilters"] = append([]string{
+			key.TenantEndpoint(&cr, bd),
+		}, key.ExternalDNSDomainFilters(&cr)...)
+	}
+
 	ciliumValues := map[string]interface{}{
 		"ipam": map[string]interface{}{
 			"mode": "kubernetes",
@@ -186,6 +443,13 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 		},
 	}
 
+	clusterValues := map[string]interface{}{}
+	// The mesh ID is allocated by the meshid resource, which cancels the
+	// reconciliation until the Cluster CR carries the allocated ID.
+	if id, ok := key.CiliumClusterMeshID(&cr); ok {
+		clusterValues["id"] = id
+	}
+
 	// We only need this if the cluster is in overlay mode during the upgrade
 	if key.ForceDisableCiliumKubeProxyReplacement(cr) && !key.CiliumEniModeEnabled(cr) {
 		ciliumValues["kubeProxyReplacement"] = "disabled"
@@ -215,16 +479,25 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 		}
 
 		var awsOperatorRelease string
+		var ciliumRelease string
 		for _, v := range re.Spec.Components {
 			if v.Name == "aws-operator" {
 				awsOperatorRelease = v.Version
 			}
+			if v.Name == "cilium" {
+				ciliumRelease = v.Version
+			}
 		}
 
 		if awsOperatorRelease == "" {
 			return nil, microerror.Mask(releaseNotFound)
 		}
 
+		eniOptions, err := r.ciliumENIOptions(ctx, cr, ciliumRelease)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
 		// This is a hack to only introduce the selector during the upgrade on the new nodes, old ones work with AWS CNI
 		if key.ForceDisableCiliumKubeProxyReplacement(cr) {
 			ciliumValues["nodeSelector"] = map[string]interface{}{
@@ -232,10 +505,20 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 			}
 		}
 
-		ciliumValues["eni"] = map[string]interface{}{
-			"enabled": true,
-			//"awsEnablePrefixDelegation": true,
+		eniValues := map[string]interface{}{
+			"enabled":                   true,
+			"awsEnablePrefixDelegation": eniOptions.PrefixDelegation,
+		}
+		if eniOptions.PreAllocate > 0 {
+			eniValues["preAllocate"] = eniOptions.PreAllocate
+		}
+		if len(eniOptions.SecurityGroupTags) > 0 {
+			eniValues["securityGroupTags"] = key.CiliumENITagsList(eniOptions.SecurityGroupTags)
 		}
+		if len(eniOptions.SubnetTags) > 0 {
+			eniValues["subnetTagsFilter"] = key.CiliumENITagsList(eniOptions.SubnetTags)
+		}
+		ciliumValues["eni"] = eniValues
 
 		ciliumValues["ipam"] = map[string]interface{}{
 			"mode": "eni",
@@ -248,16 +531,14 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 
 		ciliumValues["operator"] = map[string]interface{}{
 			"extraArgs": []string{
-				"--aws-release-excess-ips=true",
+				fmt.Sprintf("--aws-release-excess-ips=%t", eniOptions.ReleaseExcessIPs),
 			},
 		}
 
 		ciliumValues["enableIPv4Masquerade"] = false
 		ciliumValues["tunnel"] = "disabled"
 		// Used by cilium to tag ENIs it creates and be able to filter and clean them up.
-		ciliumValues["cluster"] = map[string]interface{}{
-			"name": key.ClusterID(&cr),
-		}
+		clusterValues["name"] = key.ClusterID(&cr)
 		ciliumValues["cni"] = map[string]interface{}{
 			"customConf": true,
 			"exclusive":  true,
@@ -272,22 +553,22 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 
 	}
 
+	if len(clusterValues) > 0 {
+		ciliumValues["cluster"] = clusterValues
+	}
+
 	configMapSpecs := []configMapSpec{
 		{
-			Name:      key.ClusterConfigMapName(&cr),
-			Namespace: key.ClusterID(&cr),
-			Values:    values,
+			Name:         key.ClusterConfigMapName(&cr),
+			Namespace:    key.ClusterID(&cr),
+			Values:       values,
+			SecretName:   key.ClusterSecretValuesName(&cr),
+			SecretValues: secretValues,
 		},
 		{
 			Name:      "ingress-controller-values",
 			Namespace: key.ClusterID(&cr),
-			Values: map[string]interface{}{
-				"baseDomain": key.TenantEndpoint(&cr, bd),
-				"clusterID":  key.ClusterID(&cr),
-				"configmap": map[string]interface{}{
-					"use-proxy-protocol": strconv.FormatBool(useProxyProtocol),
-				},
-			},
+			Values:    ingressValues,
 		},
 		{
 			Name:      "cilium-user-values",
@@ -295,9 +576,11 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 			Values:    ciliumValues,
 		},
 		{
-			Name:      "external-dns-cluster-values",
-			Namespace: key.ClusterID(&cr),
-			Values:    externalDnsValues,
+			Name:         "external-dns-cluster-values",
+			Namespace:    key.ClusterID(&cr),
+			Values:       externalDnsValues,
+			SecretName:   externalDNSSecretValuesName,
+			SecretValues: externalDnsSecretValues,
 			Labels: map[string]string{
 				"app.kubernetes.io/name": "external-dns",
 			},
@@ -307,26 +590,42 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 		},
 	}
 
-	var configMaps []*corev1.ConfigMap
+	return configMapSpecs, nil
+}
 
-	for _, spec := range configMapSpecs {
-		configMap, err := newConfigMap(cr, spec)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
+func newConfigMap(cr apiv1beta1.Cluster, configMapSpec configMapSpec) (*corev1.ConfigMap, error) {
+	yamlValues, err := yaml.Marshal(configMapSpec.Values)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
 
-		configMaps = append(configMaps, configMap)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: newObjectMeta(cr, configMapSpec, configMapSpec.Name),
+		Data: map[string]string{
+			"values": string(yamlValues),
+		},
 	}
 
-	return configMaps, nil
+	return cm, nil
 }
 
-func newConfigMap(cr apiv1beta1.Cluster, configMapSpec configMapSpec) (*corev1.ConfigMap, error) {
-	yamlValues, err := yaml.Marshal(configMapSpec.Values)
+func newSecret(cr apiv1beta1.Cluster, configMapSpec configMapSpec) (*corev1.Secret, error) {
+	yamlValues, err := yaml.Marshal(configMapSpec.SecretValues)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
 
+	secret := &corev1.Secret{
+		ObjectMeta: newObjectMeta(cr, configMapSpec, configMapSpec.SecretName),
+		Data: map[string][]byte{
+			"values": yamlValues,
+		},
+	}
+
+	return secret, nil
+}
+
+func newObjectMeta(cr apiv1beta1.Cluster, configMapSpec configMapSpec, name string) metav1.ObjectMeta {
 	annotations := map[string]string{
 		annotation.Notes: fmt.Sprintf("DO NOT EDIT. Values managed by %s.", project.Name()),
 	}
@@ -344,17 +643,10 @@ func newConfigMap(cr apiv1beta1.Cluster, configMapSpec configMapSpec) (*corev1.C
 		labels[k] = v
 	}
 
-	cm := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        configMapSpec.Name,
-			Namespace:   configMapSpec.Namespace,
-			Annotations: annotations,
-			Labels:      labels,
-		},
-		Data: map[string]string{
-			"values": string(yamlValues),
-		},
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   configMapSpec.Namespace,
+		Annotations: annotations,
+		Labels:      labels,
 	}
-
-	return cm, nil
 }
@@ -0,0 +1,535 @@
+package clusterconfigmap
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	providerv1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/proxy"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+type baseDomain struct{}
+
+func (b baseDomain) BaseDomain(ctx context.Context, obj interface{}) (string, error) {
+	return "gauss.eu-west-1.aws.gigantic.io", nil
+}
+
+type podCIDR struct{}
+
+func (p podCIDR) PodCIDR(ctx context.Context, obj interface{}) (string, error) {
+	return "10.2.0.0/16", nil
+}
+
+type noProxy struct{}
+
+func (p noProxy) Settings(ctx context.Context, obj interface{}) (proxy.Settings, error) {
+	return proxy.Settings{}, nil
+}
+
+func Test_Resource_ExternalDNSValues(t *testing.T) {
+	testCases := []struct {
+		name        string
+		provider    string
+		annotations map[string]string
+		ctrlObjects []client.Object
+		k8sObjects  []runtime.Object
+
+		expectCanceled     bool
+		expectValues       map[string]interface{}
+		expectSecretValues map[string]interface{}
+	}{
+		{
+			name:     "case 0: aws with private hosted zone",
+			provider: label.ProviderAWS,
+			annotations: map[string]string{
+				annotation.ExternalDNSZoneType: "private",
+			},
+			ctrlObjects: []client.Object{
+				newTestAWSCluster(),
+			},
+			k8sObjects: []runtime.Object{
+				newTestAPISecret(),
+				newTestCredentialSecret(map[string][]byte{
+					"aws.awsoperator.arn": []byte("arn:aws:iam::123456789012:role/GiantSwarmAWSOperator"),
+				}),
+			},
+			expectValues: map[string]interface{}{
+				"annotationFilter": "giantswarm.io/external-dns=managed",
+				"aws": map[string]interface{}{
+					"batchChangeInterval": nil,
+				},
+				"domainFilters": []interface{}{
+					"8y5ck.k8s.gauss.eu-west-1.aws.gigantic.io",
+				},
+				"extraArgs": []interface{}{
+					"--aws-batch-change-interval=10s",
+					"--aws-zone-type=private",
+				},
+				"sources": []interface{}{
+					"service",
+				},
+				"txtOwnerId": "giantswarm-io-external-dns",
+				"txtPrefix":  "8y5ck",
+			},
+			expectSecretValues: map[string]interface{}{
+				"serviceAccount": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/8y5ck-Route53Manager-Role",
+					},
+				},
+			},
+		},
+		{
+			name:     "case 1: azure",
+			provider: label.ProviderAzure,
+			ctrlObjects: []client.Object{
+				newTestAzureConfig(),
+			},
+			k8sObjects: []runtime.Object{
+				newTestAPISecret(),
+				newTestCredentialSecret(map[string][]byte{
+					"azure.azureoperator.subscriptionid": []byte("c8a2b2f6-subscription"),
+					"azure.azureoperator.tenantid":       []byte("31f75bf9-tenant"),
+				}),
+			},
+			expectValues: map[string]interface{}{
+				"annotationFilter": "giantswarm.io/external-dns=managed",
+				"azure": map[string]interface{}{
+					"resourceGroup":               "gauss",
+					"useManagedIdentityExtension": true,
+				},
+				"domainFilters": []interface{}{
+					"8y5ck.k8s.gauss.eu-west-1.aws.gigantic.io",
+				},
+				"provider": "azure",
+				"sources": []interface{}{
+					"service",
+				},
+				"txtOwnerId": "giantswarm-io-external-dns",
+				"txtPrefix":  "8y5ck",
+			},
+			expectSecretValues: map[string]interface{}{
+				"azure": map[string]interface{}{
+					"subscriptionId": "c8a2b2f6-subscription",
+					"tenantId":       "31f75bf9-tenant",
+				},
+			},
+		},
+		{
+			name:     "case 2: azure without credential secret",
+			provider: label.ProviderAzure,
+			ctrlObjects: []client.Object{
+				newTestAzureConfig(),
+			},
+			k8sObjects: []runtime.Object{
+				newTestAPISecret(),
+			},
+			expectCanceled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := resourcecanceledcontext.NewContext(context.Background(), make(chan struct{}))
+
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+			for _, o := range tc.ctrlObjects {
+				err := ctrlClient.Create(ctx, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var r *Resource
+			{
+				c := Config{
+					BaseDomain: baseDomain{},
+					CtrlClient: ctrlClient,
+					Event:      recorder.New(recorder.Config{K8sClient: k8sclienttest.NewEmpty()}),
+					K8sClient:  fakek8s.NewSimpleClientset(tc.k8sObjects...),
+					Logger:     microloggertest.New(),
+					PodCIDR:    podCIDR{},
+					Proxy:      noProxy{},
+
+					ClusterIPRange: "172.31.0.0/16",
+					DNSIP:          "172.31.0.10",
+					Installation:   "gauss",
+					Provider:       tc.provider,
+					RegistryDomain: "gsoci.azurecr.io",
+				}
+
+				var err error
+				r, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cluster := newTestCluster(tc.annotations)
+
+			specs, err := r.desiredSpecs(ctx, *cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resourcecanceledcontext.IsCanceled(ctx) != tc.expectCanceled {
+				t.Fatalf("canceled == %t, want %t", resourcecanceledcontext.IsCanceled(ctx), tc.expectCanceled)
+			}
+			if tc.expectCanceled {
+				return
+			}
+
+			var spec configMapSpec
+			for _, s := range specs {
+				if s.Name == "external-dns-cluster-values" {
+					spec = s
+				}
+			}
+
+			if !cmp.Equal(normalize(t, spec.Values), tc.expectValues) {
+				t.Fatalf("values\n\n%s\n", cmp.Diff(normalize(t, spec.Values), tc.expectValues))
+			}
+			if !cmp.Equal(normalize(t, spec.SecretValues), tc.expectSecretValues) {
+				t.Fatalf("secret values\n\n%s\n", cmp.Diff(normalize(t, spec.SecretValues), tc.expectSecretValues))
+			}
+		})
+	}
+}
+
+func Test_Resource_InvalidAnnotations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		spec        string
+		path        []string
+
+		expectValue interface{}
+	}{
+		{
+			name: "case 0: invalid ingress load balancer falls back to the provider default",
+			annotations: map[string]string{
+				annotation.IngressLoadBalancerType: "nlb",
+				annotation.IngressProxyProtocol:    "true",
+			},
+			spec:        "ingress-controller-values",
+			path:        []string{"configmap", "use-proxy-protocol"},
+			expectValue: "true",
+		},
+		{
+			name: "case 1: invalid registry domain falls back to the installation default",
+			annotations: map[string]string{
+				annotation.RegistryDomain: "https://example.com",
+			},
+			spec:        "8y5ck-cluster-values",
+			path:        []string{"global", "image", "registry"},
+			expectValue: "gsoci.azurecr.io",
+		},
+		{
+			name: "case 2: invalid registry mirrors fall back to the installation default",
+			annotations: map[string]string{
+				annotation.RegistryMirrors: "mirror.example.com,https://example.com",
+			},
+			spec:        "8y5ck-cluster-values",
+			path:        []string{"global", "image", "mirrors"},
+			expectValue: []interface{}{"mirror.gcr.io"},
+		},
+		{
+			name: "case 3: invalid external-dns zone type falls back to public hosted zones",
+			annotations: map[string]string{
+				annotation.ExternalDNSZoneType: "internal",
+			},
+			spec:        "external-dns-cluster-values",
+			path:        []string{"extraArgs"},
+			expectValue: []interface{}{"--aws-batch-change-interval=10s", "--aws-zone-type=public"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+			err := ctrlClient.Create(ctx, newTestAWSCluster())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			k8sObjects := []runtime.Object{
+				newTestAPISecret(),
+				newTestCredentialSecret(map[string][]byte{
+					"aws.awsoperator.arn": []byte("arn:aws:iam::123456789012:role/GiantSwarmAWSOperator"),
+				}),
+			}
+
+			r, err := New(Config{
+				BaseDomain: baseDomain{},
+				CtrlClient: ctrlClient,
+				Event:      recorder.New(recorder.Config{K8sClient: k8sclienttest.NewEmpty()}),
+				K8sClient:  fakek8s.NewSimpleClientset(k8sObjects...),
+				Logger:     microloggertest.New(),
+				PodCIDR:    podCIDR{},
+				Proxy:      noProxy{},
+
+				ClusterIPRange:  "172.31.0.0/16",
+				DNSIP:           "172.31.0.10",
+				Installation:    "gauss",
+				Provider:        label.ProviderAWS,
+				RegistryDomain:  "gsoci.azurecr.io",
+				RegistryMirrors: []string{"mirror.gcr.io"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The cluster values of all apps are still written.
+			specs, err := r.desiredSpecs(ctx, *newTestCluster(tc.annotations))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var value interface{}
+			for _, s := range specs {
+				if s.Name != tc.spec {
+					continue
+				}
+
+				value = normalize(t, s.Values)
+				for _, p := range tc.path {
+					m, ok := value.(map[string]interface{})
+					if !ok {
+						t.Fatalf("path %v not found in values of %#q", tc.path, tc.spec)
+					}
+					value = m[p]
+				}
+			}
+
+			if !cmp.Equal(value, tc.expectValue) {
+				t.Fatalf("value\n\n%s\n", cmp.Diff(value, tc.expectValue))
+			}
+		})
+	}
+}
+
+// normalize renders the given values the way they end up in the config maps
+// and Secrets, so that they can be compared independent of their Go types.
+func normalize(t *testing.T, values map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	b, err := yaml.Marshal(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]interface{}
+	err = yaml.Unmarshal(b, &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func newTestCluster(annotations map[string]string) *apiv1beta1.Cluster {
+	return &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "8y5ck",
+			Namespace:   "org-giantswarm",
+			Annotations: annotations,
+			Labels: map[string]string{
+				label.Cluster:        "8y5ck",
+				label.Organization:   "giantswarm",
+				label.ReleaseVersion: "20.0.0",
+			},
+		},
+	}
+}
+
+func newTestAPISecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck-api",
+			Namespace: "org-giantswarm",
+		},
+		Data: map[string][]byte{
+			"ca": []byte("ca"),
+		},
+	}
+}
+
+func newTestCredentialSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "credential-default",
+			Namespace: "giantswarm",
+		},
+		Data: data,
+	}
+}
+
+func newTestAWSCluster() *infrastructurev1alpha3.AWSCluster {
+	return &infrastructurev1alpha3.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck",
+			Namespace: "org-giantswarm",
+		},
+		Spec: infrastructurev1alpha3.AWSClusterSpec{
+			Provider: infrastructurev1alpha3.AWSClusterSpecProvider{
+				CredentialSecret: infrastructurev1alpha3.AWSClusterSpecProviderCredentialSecret{
+					Name:      "credential-default",
+					Namespace: "giantswarm",
+				},
+				Region: "eu-west-1",
+			},
+		},
+	}
+}
+
+func newTestAzureConfig() *providerv1alpha1.AzureConfig {
+	return &providerv1alpha1.AzureConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "8y5ck",
+			Namespace: metav1.NamespaceDefault,
+			Labels: map[string]string{
+				label.Cluster: "8y5ck",
+			},
+		},
+		Spec: providerv1alpha1.AzureConfigSpec{
+			Azure: providerv1alpha1.AzureConfigSpecAzure{
+				CredentialSecret: providerv1alpha1.CredentialSecret{
+					Name:      "credential-default",
+					Namespace: "giantswarm",
+				},
+				DNSZones: providerv1alpha1.AzureConfigSpecAzureDNSZones{
+					API: providerv1alpha1.AzureConfigSpecAzureDNSZonesDNSZone{
+						ResourceGroup: "gauss",
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_Resource_ManagementClusterValues(t *testing.T) {
+	testCases := []struct {
+		name        string
+		provider    string
+		region      string
+		ctrlObjects []client.Object
+		k8sObjects  []runtime.Object
+
+		expectValues map[string]interface{}
+	}{
+		{
+			name:     "case 0: aws with region",
+			provider: label.ProviderAWS,
+			region:   "eu-central-1",
+			ctrlObjects: []client.Object{
+				newTestAWSCluster(),
+			},
+			k8sObjects: []runtime.Object{
+				newTestAPISecret(),
+				newTestCredentialSecret(map[string][]byte{
+					"aws.awsoperator.arn": []byte("arn:aws:iam::123456789012:role/GiantSwarmAWSOperator"),
+				}),
+			},
+			expectValues: map[string]interface{}{
+				"apiEndpoint": "https://api.gauss.eu-central-1.aws.gigantic.io",
+				"name":        "gauss",
+				"provider":    "aws",
+				"region":      "eu-central-1",
+			},
+		},
+		{
+			name:     "case 1: azure with region",
+			provider: label.ProviderAzure,
+			region:   "westeurope",
+			ctrlObjects: []client.Object{
+				newTestAzureConfig(),
+			},
+			k8sObjects: []runtime.Object{
+				newTestAPISecret(),
+				newTestCredentialSecret(map[string][]byte{}),
+			},
+			expectValues: map[string]interface{}{
+				"apiEndpoint": "https://api.gauss.eu-central-1.aws.gigantic.io",
+				"name":        "gauss",
+				"provider":    "azure",
+				"region":      "westeurope",
+			},
+		},
+		{
+			name:     "case 2: kvm without region",
+			provider: label.ProviderKVM,
+			k8sObjects: []runtime.Object{
+				newTestAPISecret(),
+			},
+			expectValues: map[string]interface{}{
+				"apiEndpoint": "https://api.gauss.eu-central-1.aws.gigantic.io",
+				"name":        "gauss",
+				"provider":    "kvm",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+			for _, o := range tc.ctrlObjects {
+				err := ctrlClient.Create(ctx, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r, err := New(Config{
+				BaseDomain: baseDomain{},
+				CtrlClient: ctrlClient,
+				Event:      recorder.New(recorder.Config{K8sClient: k8sclienttest.NewEmpty()}),
+				K8sClient:  fakek8s.NewSimpleClientset(tc.k8sObjects...),
+				Logger:     microloggertest.New(),
+				PodCIDR:    podCIDR{},
+				Proxy:      noProxy{},
+
+				ClusterIPRange:          "172.31.0.0/16",
+				DNSIP:                   "172.31.0.10",
+				Installation:            "gauss",
+				InstallationAPIEndpoint: "https://api.gauss.eu-central-1.aws.gigantic.io",
+				InstallationRegion:      tc.region,
+				Provider:                tc.provider,
+				RegistryDomain:          "gsoci.azurecr.io",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			specs, err := r.desiredSpecs(ctx, *newTestCluster(nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			values := normalize(t, specs[0].Values)
+			if !cmp.Equal(values["managementCluster"], tc.expectValues) {
+				t.Fatalf("values\n\n%s\n", cmp.Diff(values["managementCluster"], tc.expectValues))
+			}
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package clusterconfigmap
 
 import (
+	"sync"
+
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"k8s.io/client-go/kubernetes"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/giantswarm/cluster-operator/v5/service/controller/resource/clusterconfigmap/internal/cache"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/podcidr"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/proxy"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
 )
 
 const (
@@ -20,27 +25,44 @@ const (
 type Config struct {
 	BaseDomain basedomain.Interface
 	CtrlClient ctrlClient.Client
+	Event      recorder.Interface
 	K8sClient  kubernetes.Interface
 	Logger     micrologger.Logger
 	PodCIDR    podcidr.Interface
+	Proxy      proxy.Interface
 
-	ClusterIPRange string
-	DNSIP          string
-	Installation   string
-	Provider       string
+	ClusterIPRange          string
+	DNSIP                   string
+	Installation            string
+	InstallationAPIEndpoint string
+	InstallationRegion      string
+	Provider                string
+	RegistryDomain          string
+	RegistryMirrors         []string
 }
 
 // Resource implements the clusterConfigMap resource.
 type Resource struct {
 	baseDomain basedomain.Interface
 	ctrlClient ctrlClient.Client
+	event      recorder.Interface
 	k8sClient  kubernetes.Interface
 	logger     micrologger.Logger
 	podCIDR    podcidr.Interface
+	proxy      proxy.Interface
+
+	clusterIPRange          string
+	dnsIP                   string
+	installation            string
+	installationAPIEndpoint string
+	installationRegion      string
+	provider                string
+	registryDomain          string
+	registryMirrors         []string
 
-	clusterIPRange string
-	dnsIP          string
-	provider       string
+	deniedMutex     sync.Mutex
+	deniedOverrides map[string]map[string]string
+	specsCache      *cache.Specs
 }
 
 // New creates a new configured config map state getter resource managing
@@ -54,6 +76,9 @@ func New(config Config) (*Resource, error) {
 	if config.CtrlClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
 	}
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
 	if config.K8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
 	}
@@ -63,6 +88,9 @@ func New(config Config) (*Resource, error) {
 	if config.PodCIDR == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.PodCIDR must not be empty", config)
 	}
+	if config.Proxy == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Proxy must not be empty", config)
+	}
 
 	if config.ClusterIPRange == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterIPRange must not be empty", config)
@@ -76,17 +104,30 @@ func New(config Config) (*Resource, error) {
 	if config.Provider == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
 	}
+	if config.RegistryDomain == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.RegistryDomain must not be empty", config)
+	}
 
 	r := &Resource{
 		baseDomain: config.BaseDomain,
 		ctrlClient: config.CtrlClient,
+		event:      config.Event,
 		k8sClient:  config.K8sClient,
 		logger:     config.Logger,
 		podCIDR:    config.PodCIDR,
+		proxy:      config.Proxy,
+
+		clusterIPRange:          config.ClusterIPRange,
+		dnsIP:                   config.DNSIP,
+		installation:            config.Installation,
+		installationAPIEndpoint: config.InstallationAPIEndpoint,
+		installationRegion:      config.InstallationRegion,
+		provider:                config.Provider,
+		registryDomain:          config.RegistryDomain,
+		registryMirrors:         config.RegistryMirrors,
 
-		clusterIPRange: config.ClusterIPRange,
-		dnsIP:          config.DNSIP,
-		provider:       config.Provider,
+		deniedOverrides: map[string]map[string]string{},
+		specsCache:      cache.NewSpecs(),
 	}
 
 	return r, nil
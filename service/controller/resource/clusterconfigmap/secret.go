@@ -0,0 +1,100 @@
+package clusterconfigmap
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+const (
+	// SecretName is the identifier of the secret state getter.
+	SecretName = "clustersecretvalues"
+)
+
+// SecretStateGetter manages the Secrets holding the sensitive part of the
+// values computed by the Resource, so that read access to config maps does
+// not expose e.g. account identifiers.
+//
+//	https://pkg.go.dev/github.com/giantswarm/operatorkit/v8/pkg/resource/k8s/secretresource#StateGetter
+type SecretStateGetter struct {
+	resource *Resource
+}
+
+// NewSecretStateGetter creates a secret state getter based on the values
+// computed by the given Resource.
+func NewSecretStateGetter(r *Resource) (*SecretStateGetter, error) {
+	if r == nil {
+		return nil, microerror.Maskf(invalidConfigError, "resource must not be empty")
+	}
+
+	s := &SecretStateGetter{
+		resource: r,
+	}
+
+	return s, nil
+}
+
+func (s *SecretStateGetter) GetCurrentState(ctx context.Context, obj interface{}) ([]*corev1.Secret, error) {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// The secrets are deleted when the namespace is deleted.
+	if key.IsDeleted(&cr) {
+		s.resource.logger.Debugf(ctx, "not deleting secret values for tenant cluster %#q", key.ClusterID(&cr))
+		s.resource.logger.Debugf(ctx, "canceling resource")
+		resourcecanceledcontext.SetCanceled(ctx)
+		return nil, nil
+	}
+
+	var secrets []*corev1.Secret
+	for _, name := range secretNames(cr) {
+		secret, err := s.resource.k8sClient.CoreV1().Secrets(key.ClusterID(&cr)).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	s.resource.logger.Debugf(ctx, "found %d secrets with values in namespace %#q", len(secrets), key.ClusterID(&cr))
+
+	return secrets, nil
+}
+
+func (s *SecretStateGetter) GetDesiredState(ctx context.Context, obj interface{}) ([]*corev1.Secret, error) {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	specs, err := s.resource.cachedSpecs(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var secrets []*corev1.Secret
+	for _, spec := range specs {
+		if spec.SecretName == "" {
+			continue
+		}
+
+		secret, err := newSecret(cr, spec)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
@@ -1,9 +1,39 @@
 package clusterconfigmap
 
+import (
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+const (
+	externalDNSSecretValuesName = "external-dns-cluster-secret-values"
+)
+
 type configMapSpec struct {
 	Name        string
 	Namespace   string
 	Values      map[string]interface{}
 	Labels      map[string]string
 	Annotations map[string]string
+
+	// SecretName is the name of the Secret holding the SecretValues. It is
+	// created next to the config map with the same labels and annotations.
+	SecretName   string
+	SecretValues map[string]interface{}
+}
+
+// specsEntry is the result of desiredSpecs cached for the reconciliation.
+type specsEntry struct {
+	Canceled bool
+	Specs    []configMapSpec
+}
+
+// secretNames returns the names of all Secrets managed next to the config maps
+// of the given cluster.
+func secretNames(cr apiv1beta1.Cluster) []string {
+	return []string{
+		key.ClusterSecretValuesName(&cr),
+		externalDNSSecretValuesName,
+	}
 }
@@ -0,0 +1,144 @@
+package clusterconfigmap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+// applyValuesOverrides merges the values overrides defined via annotations of
+// the given Cluster CR into the cluster values. Overrides of values owned by
+// the operator are skipped. Overrides emit an event on the Cluster CR when
+// they change the rendered values, so that it is visible which values were
+// changed by whom.
+func (r *Resource) applyValuesOverrides(ctx context.Context, cr apiv1beta1.Cluster, values map[string]interface{}) error {
+	overrides, err := key.ValuesOverrides(&cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var applied []key.ValuesOverride
+	for _, o := range overrides {
+		if key.IsDeniedValuesPath(o.Path) {
+			r.logger.Debugf(ctx, "skipping values override %#q of operator owned path %#q", o.Annotation, o.Path)
+			if r.firstDenial(cr, o) {
+				r.event.Emit(ctx, &cr, "ValuesOverrideDenied", fmt.Sprintf("values override %#q of operator owned path %#q is ignored", o.Annotation, o.Path))
+			}
+			continue
+		}
+
+		err = key.ApplyValuesOverride(values, o)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		applied = append(applied, o)
+	}
+
+	if len(applied) == 0 {
+		return nil
+	}
+
+	current, err := r.currentClusterValues(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Overrides are compared after all of them are applied, since overrides
+	// of nested paths change the values of their parents.
+	for _, o := range applied {
+		changed, err := valuesPathChanged(current, values, o.Path)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if changed {
+			r.event.Emit(ctx, &cr, "ValuesOverrideApplied", fmt.Sprintf("values override %#q applied to path %#q", o.Annotation, o.Path))
+		}
+	}
+
+	return nil
+}
+
+// currentClusterValues returns the values of the cluster config map as they
+// are currently rendered. The values are empty in case the config map does
+// not exist yet.
+func (r *Resource) currentClusterValues(ctx context.Context, cr apiv1beta1.Cluster) (map[string]interface{}, error) {
+	cm, err := r.k8sClient.CoreV1().ConfigMaps(key.ClusterID(&cr)).Get(ctx, key.ClusterConfigMapName(&cr), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return map[string]interface{}{}, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal([]byte(cm.Data["values"]), &values)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return values, nil
+}
+
+// firstDenial returns true in case the denied override was not seen before
+// with its current annotation value. Denied overrides never change the
+// rendered values, which is why they are tracked in memory.
+func (r *Resource) firstDenial(cr apiv1beta1.Cluster, o key.ValuesOverride) bool {
+	k := fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)
+	v := cr.Annotations[o.Annotation]
+
+	r.deniedMutex.Lock()
+	defer r.deniedMutex.Unlock()
+
+	denied, ok := r.deniedOverrides[k]
+	if !ok {
+		denied = map[string]string{}
+		r.deniedOverrides[k] = denied
+	}
+
+	if seen, ok := denied[o.Annotation]; ok && seen == v {
+		return false
+	}
+	denied[o.Annotation] = v
+
+	return true
+}
+
+// forgetDenials drops the denied overrides tracked for the given cluster, so
+// that the memory of deleted clusters is released.
+func (r *Resource) forgetDenials(cr apiv1beta1.Cluster) {
+	r.deniedMutex.Lock()
+	defer r.deniedMutex.Unlock()
+
+	delete(r.deniedOverrides, fmt.Sprintf("%s/%s", cr.Namespace, cr.Name))
+}
+
+// valuesPathChanged compares the given path of the current and desired
+// values in their rendered YAML representation, since the current values
+// are parsed from YAML and do not have the Go types of the desired values.
+func valuesPathChanged(current, desired map[string]interface{}, path string) (bool, error) {
+	c, cok := key.LookupValuesPath(current, path)
+	d, dok := key.LookupValuesPath(desired, path)
+	if cok != dok {
+		return true, nil
+	}
+
+	cb, err := yaml.Marshal(c)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	db, err := yaml.Marshal(d)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return !bytes.Equal(cb, db), nil
+}
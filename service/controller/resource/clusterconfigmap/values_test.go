@@ -0,0 +1,137 @@
+package clusterconfigmap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+type countingRecorder struct {
+	reasons map[string]int
+}
+
+func (r *countingRecorder) Emit(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	r.reasons[reason]++
+}
+
+func (r *countingRecorder) Warn(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	r.reasons[reason]++
+}
+
+func Test_Resource_applyValuesOverrides_Events(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fakek8s.NewSimpleClientset()
+	event := &countingRecorder{reasons: map[string]int{}}
+
+	r, err := New(Config{
+		BaseDomain: baseDomain{},
+		CtrlClient: unittest.FakeK8sClient().CtrlClient(),
+		Event:      event,
+		K8sClient:  k8sClient,
+		Logger:     microloggertest.New(),
+		PodCIDR:    podCIDR{},
+		Proxy:      noProxy{},
+
+		ClusterIPRange: "172.31.0.0/16",
+		DNSIP:          "172.31.0.10",
+		Installation:   "gauss",
+		Provider:       "aws",
+		RegistryDomain: "gsoci.azurecr.io",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cr := newTestCluster(map[string]string{
+		"values.cluster-operator.giantswarm.io/global.debug": "true",
+		"values.cluster-operator.giantswarm.io/clusterID":    "al9qy",
+	})
+
+	// render applies the overrides and persists the resulting values like
+	// the config map resource does.
+	render := func() {
+		t.Helper()
+
+		values := map[string]interface{}{
+			"clusterID": "8y5ck",
+			"global": map[string]interface{}{
+				"debug": false,
+			},
+		}
+
+		err := r.applyValuesOverrides(ctx, *cr, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := yaml.Marshal(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.ClusterConfigMapName(cr),
+				Namespace: key.ClusterID(cr),
+			},
+			Data: map[string]string{
+				"values": string(b),
+			},
+		}
+
+		_ = k8sClient.CoreV1().ConfigMaps(cm.Namespace).Delete(ctx, cm.Name, metav1.DeleteOptions{})
+		_, err = k8sClient.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expect := func(applied, denied int) {
+		t.Helper()
+
+		if event.reasons["ValuesOverrideApplied"] != applied {
+			t.Fatalf("ValuesOverrideApplied events == %d, want %d", event.reasons["ValuesOverrideApplied"], applied)
+		}
+		if event.reasons["ValuesOverrideDenied"] != denied {
+			t.Fatalf("ValuesOverrideDenied events == %d, want %d", event.reasons["ValuesOverrideDenied"], denied)
+		}
+	}
+
+	render()
+	expect(1, 1)
+
+	// Reconciling unchanged overrides must not emit events again.
+	render()
+	render()
+	expect(1, 1)
+
+	// Changing the overrides emits events again.
+	cr.Annotations["values.cluster-operator.giantswarm.io/global.debug"] = "false"
+	cr.Annotations["values.cluster-operator.giantswarm.io/clusterID"] = "b3x7q"
+	render()
+	expect(2, 2)
+
+	// Denied overrides of deleted clusters are forgotten.
+	{
+		deleted := cr.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		_, err := r.GetCurrentState(resourcecanceledcontext.NewContext(ctx, make(chan struct{})), deleted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.deniedOverrides) != 0 {
+			t.Fatalf("denied overrides == %d, want %d", len(r.deniedOverrides), 0)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package clusterdeleting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/finalizerskeptcontext"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	cl, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if r.durations != nil {
+		r.observeDeletion(ctx, cl)
+		return nil
+	}
+
+	clusterUpdated, err := r.ensureClusterCondition(ctx, cl)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var infraUpdated bool
+	if r.provider == label.ProviderAWS {
+		infraUpdated, err = r.ensureInfraCondition(ctx, cl)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if clusterUpdated || infraUpdated {
+		r.logger.Debugf(ctx, "canceling reconciliation")
+		reconciliationcanceledcontext.SetCanceled(ctx)
+		r.logger.Debugf(ctx, "keeping finalizers")
+		finalizerskeptcontext.SetKept(ctx)
+	}
+
+	return nil
+}
+
+// observeDeletion observes the duration of the deletion of the Cluster CR
+// since its deletion timestamp in case no resource keeps the finalizers, which
+// means that the cluster controller releases them once this pass completed.
+func (r *Resource) observeDeletion(ctx context.Context, cl apiv1beta1.Cluster) {
+	if cl.DeletionTimestamp == nil {
+		return
+	}
+	if finalizerskeptcontext.IsKept(ctx) {
+		r.logger.Debugf(ctx, "not observing deletion duration since finalizers are kept")
+		return
+	}
+
+	d := time.Since(cl.DeletionTimestamp.Time)
+	r.durations.WithLabelValues(r.provider, key.ReleaseVersion(&cl), transition.Delete).Observe(d.Seconds())
+
+	r.logger.Debugf(ctx, "observed deletion duration of %s", d.Round(time.Second))
+}
+
+// ensureClusterCondition sets the Deleting condition of the Cluster CR and
+// emits an event once the deletion started.
+func (r *Resource) ensureClusterCondition(ctx context.Context, cl apiv1beta1.Cluster) (bool, error) {
+	var cr apiv1beta1.Cluster
+	{
+		err := r.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		}
+	}
+
+	if conditions.Has(&cr, key.DeletingCondition) {
+		return false, nil
+	}
+
+	since := time.Now()
+	if cr.DeletionTimestamp != nil {
+		since = cr.DeletionTimestamp.Time
+	}
+
+	c := conditions.TrueCondition(key.DeletingCondition)
+	c.Message = fmt.Sprintf("Deletion started at %s.", since.UTC().Format(time.RFC3339))
+	conditions.Set(&cr, c)
+
+	r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("setting %#q condition", key.DeletingCondition))
+
+	err := r.k8sClient.CtrlClient().Status().Update(ctx, &cr)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	r.event.Emit(ctx, &cr, "ClusterDeleting", fmt.Sprintf("cluster is in condition %s", key.DeletingCondition))
+
+	return true, nil
+}
+
+// ensureInfraCondition adds the Deleting status condition to the
+// infrastructure CR, which is exported by the cluster collectors.
+func (r *Resource) ensureInfraCondition(ctx context.Context, cl apiv1beta1.Cluster) (bool, error) {
+	cr := r.newCommonClusterObjectFunc()
+	{
+		r.logger.Debugf(ctx, "finding latest infrastructure reference for cluster %#q", key.ClusterID(&cl))
+
+		err := r.k8sClient.CtrlClient().Get(ctx, key.ObjRefToNamespacedName(key.ObjRefFromCluster(cl)), cr)
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find latest infrastructure reference for cluster %#q", key.ClusterID(&cl))
+			return false, nil
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "found latest infrastructure reference for cluster %#q", key.ClusterID(&cl))
+	}
+
+	status := cr.GetCommonClusterStatus()
+
+	// We skip adding the condition if it's already set so the transition time
+	// reflects the start of the deletion.
+	if status.HasDeletingCondition() {
+		return false, nil
+	}
+
+	status.Conditions = status.WithDeletingCondition()
+	cr.SetCommonClusterStatus(status)
+
+	r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("setting %#q status condition", infrastructurev1alpha3.ClusterStatusConditionDeleting))
+
+	err := r.k8sClient.CtrlClient().Status().Update(ctx, cr)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
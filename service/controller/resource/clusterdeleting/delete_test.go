@@ -0,0 +1,215 @@
+package clusterdeleting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/finalizerskeptcontext"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_ClusterDeleting_EnsureDeleted(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider string
+
+		expectInfraDeleting bool
+	}{
+		{
+			name:     "case 0: aws",
+			provider: label.ProviderAWS,
+
+			expectInfraDeleting: true,
+		},
+		{
+			name:     "case 1: kvm",
+			provider: label.ProviderKVM,
+
+			expectInfraDeleting: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := unittest.FakeK8sClient()
+
+			infra := unittest.DefaultCluster()
+			err := k8sClient.CtrlClient().Create(context.Background(), &infra)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      unittest.DefaultClusterID,
+					Namespace: metav1.NamespaceDefault,
+					Labels: map[string]string{
+						label.Cluster: unittest.DefaultClusterID,
+					},
+				},
+				Spec: apiv1beta1.ClusterSpec{
+					InfrastructureRef: &corev1.ObjectReference{
+						Kind:      "AWSCluster",
+						Name:      infra.Name,
+						Namespace: infra.Namespace,
+					},
+				},
+			}
+			err = k8sClient.CtrlClient().Create(context.Background(), cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var r *Resource
+			{
+				c := Config{
+					Event:     recorder.New(recorder.Config{K8sClient: k8sclienttest.NewEmpty()}),
+					K8sClient: k8sClient,
+					Logger:    microloggertest.New(),
+
+					NewCommonClusterObjectFunc: func() infrastructurev1alpha3.CommonClusterObject {
+						return new(infrastructurev1alpha3.AWSCluster)
+					},
+					Provider: tc.provider,
+				}
+
+				r, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// The first deletion sets the conditions and cancels the
+			// reconciliation, the second one leaves them untouched.
+			for i := 0; i < 2; i++ {
+				ctx := reconciliationcanceledcontext.NewContext(context.Background(), make(chan struct{}))
+
+				err = r.EnsureDeleted(ctx, cluster)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				canceled := reconciliationcanceledcontext.IsCanceled(ctx)
+				if canceled != (i == 0) {
+					t.Fatalf("expected reconciliation canceled to be %t in run %d", i == 0, i)
+				}
+			}
+
+			var updated apiv1beta1.Cluster
+			err = k8sClient.CtrlClient().Get(context.Background(), types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !conditions.IsTrue(&updated, key.DeletingCondition) {
+				t.Fatalf("expected %#q condition to be true", key.DeletingCondition)
+			}
+
+			var updatedInfra infrastructurev1alpha3.AWSCluster
+			err = k8sClient.CtrlClient().Get(context.Background(), types.NamespacedName{Name: infra.Name, Namespace: infra.Namespace}, &updatedInfra)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updatedInfra.Status.Cluster.HasDeletingCondition() != tc.expectInfraDeleting {
+				t.Fatalf("expected infrastructure CR deleting condition to be %t", tc.expectInfraDeleting)
+			}
+			if tc.expectInfraDeleting && updatedInfra.Status.Cluster.LatestCondition() != infrastructurev1alpha3.ClusterStatusConditionDeleting {
+				t.Fatalf("expected latest condition %#q, got %#q", infrastructurev1alpha3.ClusterStatusConditionDeleting, updatedInfra.Status.Cluster.LatestCondition())
+			}
+		})
+	}
+}
+
+func Test_ClusterDeleting_EnsureDeleted_Duration(t *testing.T) {
+	testCases := []struct {
+		name              string
+		deletionTimestamp *metav1.Time
+		finalizersKept    bool
+
+		expectObservations uint64
+	}{
+		{
+			name:               "case 0: finalizers are released",
+			deletionTimestamp:  &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			expectObservations: 1,
+		},
+		{
+			name:               "case 1: finalizers are kept",
+			deletionTimestamp:  &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			finalizersKept:     true,
+			expectObservations: 0,
+		},
+		{
+			name:               "case 2: cluster is not deleted",
+			expectObservations: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			durations := transition.NewDurations()
+
+			r, err := New(Config{
+				Event:     recorder.New(recorder.Config{K8sClient: k8sclienttest.NewEmpty()}),
+				K8sClient: unittest.FakeK8sClient(),
+				Logger:    microloggertest.New(),
+
+				Durations: durations,
+				NewCommonClusterObjectFunc: func() infrastructurev1alpha3.CommonClusterObject {
+					return new(infrastructurev1alpha3.AWSCluster)
+				},
+				Provider: label.ProviderAWS,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              unittest.DefaultClusterID,
+					Namespace:         metav1.NamespaceDefault,
+					DeletionTimestamp: tc.deletionTimestamp,
+					Labels: map[string]string{
+						label.Cluster:        unittest.DefaultClusterID,
+						label.ReleaseVersion: "20.0.0",
+					},
+				},
+			}
+
+			ctx := finalizerskeptcontext.NewContext(context.Background(), make(chan struct{}))
+			if tc.finalizersKept {
+				finalizerskeptcontext.SetKept(ctx)
+			}
+
+			err = r.EnsureDeleted(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var m dto.Metric
+			err = durations.WithLabelValues(label.ProviderAWS, "20.0.0", transition.Delete).(prometheus.Metric).Write(&m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c := m.GetHistogram().GetSampleCount(); c != tc.expectObservations {
+				t.Fatalf("delete observations == %d, want %d", c, tc.expectObservations)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package clusterdeleting
+
+import (
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+)
+
+const (
+	Name = "clusterdeleting"
+)
+
+type Config struct {
+	Event     recorder.Interface
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	// Durations is the histogram the durations of completed deletions are
+	// observed in. When set, the resource only observes the deletion on the
+	// pass which releases the finalizers of the Cluster CR. It must then be
+	// wired after all resources keeping finalizers.
+	Durations *prometheus.HistogramVec
+
+	NewCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
+	Provider                   string
+}
+
+// Resource marks clusters as deleting as soon as the deletion timestamp of the
+// Cluster CR appears. It sets the Deleting condition of the Cluster CR for all
+// providers and the Deleting status condition of the infrastructure CR on AWS.
+// The resource must be the first one of the cluster controller so that no
+// other resource canceling the reconciliation delays it. A second instance
+// configured with Durations observes the duration of the deletion and must be
+// the last one of the cluster controller.
+type Resource struct {
+	event     recorder.Interface
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+
+	durations *prometheus.HistogramVec
+
+	newCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
+	provider                   string
+}
+
+func New(config Config) (*Resource, error) {
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.NewCommonClusterObjectFunc == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
+	}
+	if config.Provider == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
+	}
+
+	r := &Resource{
+		event:     config.Event,
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		durations: config.Durations,
+
+		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+		provider:                   config.Provider,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
@@ -0,0 +1,84 @@
+package clustertransition
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cl, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if r.provider != label.ProviderAWS {
+		r.logger.Debugf(ctx, "cluster transitions are not known for provider %#q", r.provider)
+		return nil
+	}
+
+	cr := r.newCommonClusterObjectFunc()
+	{
+		err = r.k8sClient.CtrlClient().Get(ctx, key.ObjRefToNamespacedName(key.ObjRefFromCluster(cl)), cr)
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find infrastructure reference for cluster %#q", key.ClusterID(&cl))
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, t := range transition.Completed(cr.GetCommonClusterStatus()) {
+		err = r.observe(ctx, cl, t)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// observe records the given transition in the marker annotation of the
+// Cluster CR and observes its duration, unless it was observed already.
+func (r *Resource) observe(ctx context.Context, cl apiv1beta1.Cluster, t transition.Transition) error {
+	// Fetch the latest version of the Cluster CR since the one we reconcile
+	// may not reflect the marker of a previous reconciliation yet.
+	var cr apiv1beta1.Cluster
+	{
+		err := r.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if cr.Annotations[t.Annotation] == t.Marker() {
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "observing %s transition of cluster", t.Name)
+
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+	cr.Annotations[t.Annotation] = t.Marker()
+
+	// The update fails on conflicts, so that concurrent reconciliations never
+	// both observe the same transition.
+	err := r.k8sClient.CtrlClient().Update(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.durations.WithLabelValues(r.provider, key.ReleaseVersion(&cr), t.Name).Observe(t.End.Sub(t.Start).Seconds())
+
+	r.logger.Debugf(ctx, "observed %s transition of cluster", t.Name)
+
+	return nil
+}
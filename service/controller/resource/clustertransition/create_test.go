@@ -0,0 +1,171 @@
+package clustertransition
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_ClusterTransition_EnsureCreated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider string
+		status   infrastructurev1alpha3.CommonClusterStatus
+
+		expectCreate uint64
+		expectUpdate uint64
+	}{
+		{
+			name:     "case 0: the cluster is creating",
+			provider: label.ProviderAWS,
+			status: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetCreatingCondition(20),
+				},
+			},
+			expectCreate: 0,
+			expectUpdate: 0,
+		},
+		{
+			name:     "case 1: the cluster is created and updating",
+			provider: label.ProviderAWS,
+			status: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetUpdatingCondition(30),
+					unittest.GetCreatedCondition(60),
+					unittest.GetCreatingCondition(90),
+				},
+			},
+			expectCreate: 1,
+			expectUpdate: 0,
+		},
+		{
+			name:     "case 2: the cluster is updated",
+			provider: label.ProviderAWS,
+			status: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetUpdatedCondition(10),
+					unittest.GetUpdatingCondition(30),
+					unittest.GetCreatedCondition(60),
+					unittest.GetCreatingCondition(90),
+				},
+			},
+			expectCreate: 1,
+			expectUpdate: 1,
+		},
+		{
+			name:     "case 3: transitions are not known for other providers",
+			provider: label.ProviderAzure,
+			status: infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+					unittest.GetCreatedCondition(60),
+					unittest.GetCreatingCondition(90),
+				},
+			},
+			expectCreate: 0,
+			expectUpdate: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := unittest.FakeK8sClient()
+
+			awsCluster := &infrastructurev1alpha3.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck",
+					Namespace: "org-giantswarm",
+				},
+				Status: infrastructurev1alpha3.AWSClusterStatus{
+					Cluster: tc.status,
+				},
+			}
+			err := k8sClient.CtrlClient().Create(ctx, awsCluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cl := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck",
+					Namespace: "org-giantswarm",
+				},
+				Spec: apiv1beta1.ClusterSpec{
+					InfrastructureRef: &corev1.ObjectReference{
+						Kind:      "AWSCluster",
+						Name:      "8y5ck",
+						Namespace: "org-giantswarm",
+					},
+				},
+			}
+			err = k8sClient.CtrlClient().Create(ctx, cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Reconciling twice and once more with a fresh resource, as after
+			// a restart, must not observe any transition twice. The reconciled
+			// Cluster CR is never updated to simulate a cache which does not
+			// reflect the marker annotations yet.
+			var create, update uint64
+			for i := 0; i < 2; i++ {
+				durations := transition.NewDurations()
+
+				r, err := New(Config{
+					Durations: durations,
+					K8sClient: k8sClient,
+					Logger:    microloggertest.New(),
+
+					NewCommonClusterObjectFunc: func() infrastructurev1alpha3.CommonClusterObject {
+						return &infrastructurev1alpha3.AWSCluster{}
+					},
+					Provider: tc.provider,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for j := 0; j < 2; j++ {
+					err = r.EnsureCreated(ctx, cl.DeepCopy())
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				create += sampleCount(t, durations, tc.provider, transition.Create)
+				update += sampleCount(t, durations, tc.provider, transition.Update)
+			}
+
+			if create != tc.expectCreate {
+				t.Fatalf("create observations == %d, want %d", create, tc.expectCreate)
+			}
+			if update != tc.expectUpdate {
+				t.Fatalf("update observations == %d, want %d", update, tc.expectUpdate)
+			}
+		})
+	}
+}
+
+func sampleCount(t *testing.T, durations *prometheus.HistogramVec, provider string, name string) uint64 {
+	t.Helper()
+
+	var m dto.Metric
+	err := durations.WithLabelValues(provider, "", name).(prometheus.Metric).Write(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m.GetHistogram().GetSampleCount()
+}
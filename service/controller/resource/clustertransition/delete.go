@@ -0,0 +1,11 @@
+package clustertransition
+
+import (
+	"context"
+)
+
+// EnsureDeleted is a no-op since deletions are observed by the cluster
+// transition collector once the Cluster CR is gone.
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package clustertransition
+
+import (
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Name = "clustertransition"
+)
+
+type Config struct {
+	Durations *prometheus.HistogramVec
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	NewCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
+	Provider                   string
+}
+
+// Resource observes completed creation and update transitions of tenant
+// clusters in the transition duration histogram exposed by the cluster
+// transition collector. Every observation is recorded in an annotation of the
+// Cluster CR before it is made, so that restarts of the operator never observe
+// a transition twice. Transitions are only known for AWS, where they are
+// reported in the status conditions of the infrastructure CR.
+type Resource struct {
+	durations *prometheus.HistogramVec
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+
+	newCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
+	provider                   string
+}
+
+func New(config Config) (*Resource, error) {
+	if config.Durations == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Durations must not be empty", config)
+	}
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.NewCommonClusterObjectFunc == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
+	}
+	if config.Provider == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
+	}
+
+	r := &Resource{
+		durations: config.Durations,
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+		provider:                   config.Provider,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
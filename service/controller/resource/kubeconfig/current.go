@@ -2,13 +2,17 @@ package kubeconfig
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
 )
 
@@ -18,29 +22,94 @@ func (r *Resource) GetCurrentState(ctx context.Context, obj interface{}) ([]*cor
 		return nil, microerror.Mask(err)
 	}
 
-	// The secrets are deleted when the namespace is deleted.
-	if key.IsDeleted(&cr) {
-		r.logger.Debugf(ctx, "not deleting kubeconfig secret for tenant cluster %#q", key.ClusterID(&cr))
-		r.logger.Debugf(ctx, "canceling resource")
-		resourcecanceledcontext.SetCanceled(ctx)
-		return nil, nil
+	// Secrets of variants which are not configured anymore are found via
+	// their labels so that they get deleted.
+	labelled, err := r.listSecrets(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
-	var secret *corev1.Secret
-	{
-		r.logger.Debugf(ctx, "finding secret %#q for tenant cluster %#q", key.KubeConfigSecretName(&cr), key.ClusterID(&cr))
+	// The secrets in the tenant cluster namespace are deleted when the
+	// namespace is deleted. Secrets of variants living in other namespaces
+	// and mirrored Secrets are returned so that they get deleted with the
+	// tenant cluster.
+	if key.IsDeleted(&cr) {
+		var secrets []*corev1.Secret
+		for _, s := range labelled {
+			if s.Namespace != key.ClusterID(&cr) {
+				secrets = append(secrets, s)
+			}
+		}
 
-		secret, err = r.k8sClient.CoreV1().Secrets(key.ClusterID(&cr)).Get(ctx, key.KubeConfigSecretName(&cr), metav1.GetOptions{})
-		if apierrors.IsNotFound(err) {
-			r.logger.Debugf(ctx, "did not find secret %#q for tenant cluster %#q", key.KubeConfigSecretName(&cr), key.ClusterID(&cr))
+		if len(secrets) == 0 {
+			r.logger.Debugf(ctx, "not deleting kubeconfig secrets for tenant cluster %#q", key.ClusterID(&cr))
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
 			return nil, nil
+		}
+
+		return secrets, nil
+	}
 
+	secrets := labelled
+
+	// Secrets created before kubeconfig variants existed are not labelled.
+	// They are looked up by name so that they get adopted instead of being
+	// created again.
+	for _, v := range r.variants {
+		name := key.KubeConfigVariantSecretName(&cr, v)
+		namespace := key.KubeConfigVariantSecretNamespace(&cr, v)
+
+		if containsSecret(secrets, namespace, name) {
+			continue
+		}
+
+		r.logger.Debugf(ctx, "finding secret %#q in namespace %#q for tenant cluster %#q", name, namespace, key.ClusterID(&cr))
+
+		secret, err := r.k8sClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find secret %#q in namespace %#q for tenant cluster %#q", name, namespace, key.ClusterID(&cr))
+			continue
 		} else if err != nil {
 			return nil, microerror.Mask(err)
 		}
 
-		r.logger.Debugf(ctx, "found secret %#q for tenant cluster %#q", key.KubeConfigSecretName(&cr), key.ClusterID(&cr))
+		r.logger.Debugf(ctx, "found secret %#q in namespace %#q for tenant cluster %#q", name, namespace, key.ClusterID(&cr))
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+func (r *Resource) listSecrets(ctx context.Context, cr apiv1beta1.Cluster) ([]*corev1.Secret, error) {
+	r.logger.Debugf(ctx, "finding kubeconfig secrets for tenant cluster %#q", key.ClusterID(&cr))
+
+	o := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%s,%s", label.Cluster, key.ClusterID(&cr), label.ManagedBy, project.Name(), label.KubeConfigVariant),
+	}
+
+	list, err := r.k8sClient.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, o)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var secrets []*corev1.Secret
+	for i := range list.Items {
+		secrets = append(secrets, &list.Items[i])
+	}
+
+	r.logger.Debugf(ctx, "found %d kubeconfig secrets for tenant cluster %#q", len(secrets), key.ClusterID(&cr))
+
+	return secrets, nil
+}
+
+func containsSecret(secrets []*corev1.Secret, namespace, name string) bool {
+	for _, s := range secrets {
+		if s.Namespace == namespace && s.Name == name {
+			return true
+		}
 	}
 
-	return []*corev1.Secret{secret}, nil
+	return false
 }
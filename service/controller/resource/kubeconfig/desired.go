@@ -3,12 +3,15 @@ package kubeconfig
 import (
 	"context"
 
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/k8sclient/v7/pkg/k8srestconfig"
 	"github.com/giantswarm/kubeconfig/v4"
 	"github.com/giantswarm/microerror"
-	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/giantswarm/cluster-operator/v5/pkg/label"
 	"github.com/giantswarm/cluster-operator/v5/pkg/project"
@@ -24,35 +27,38 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
-	var restConfig *rest.Config
-	{
-		restConfig, err = r.tenant.NewRestConfig(ctx, key.ClusterID(&cr), key.KubeConfigEndpoint(&cr, bd))
-		if tenantcluster.IsTimeout(err) {
-			r.logger.Debugf(ctx, "timeout fetching certificates")
-			r.logger.Debugf(ctx, "canceling resource")
-			return nil, nil
 
-		} else if err != nil {
-			return nil, microerror.Mask(err)
+	var secrets []*corev1.Secret
+	for _, v := range r.variants {
+		var restConfig *rest.Config
+		{
+			restConfig, err = r.newRestConfig(ctx, cr, bd, v)
+			if certs.IsTimeout(err) {
+				r.logger.Debugf(ctx, "timeout fetching certificate %#q for kubeconfig variant %#q", key.KubeConfigVariantCertificate(v), v.Name)
+				r.logger.Debugf(ctx, "canceling resource")
+				resourcecanceledcontext.SetCanceled(ctx)
+				return nil, nil
+
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			}
 		}
-	}
 
-	var secret *corev1.Secret
-	{
 		b, err := kubeconfig.NewKubeConfigForRESTConfig(ctx, restConfig, key.KubeConfigClusterName(&cr), "")
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 
-		secret = &corev1.Secret{
+		secret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      key.KubeConfigSecretName(&cr),
-				Namespace: key.ClusterID(&cr),
+				Name:      key.KubeConfigVariantSecretName(&cr, v),
+				Namespace: key.KubeConfigVariantSecretNamespace(&cr, v),
 				Labels: map[string]string{
-					label.Cluster:      key.ClusterID(&cr),
-					label.ManagedBy:    project.Name(),
-					label.Organization: key.OrganizationID(&cr),
-					label.ServiceType:  label.ServiceTypeManaged,
+					label.Cluster:           key.ClusterID(&cr),
+					label.KubeConfigVariant: v.Name,
+					label.ManagedBy:         project.Name(),
+					label.Organization:      key.OrganizationID(&cr),
+					label.ServiceType:       label.ServiceTypeManaged,
 				},
 			},
 			Data: map[string][]byte{
@@ -62,7 +68,57 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 				"value": b,
 			},
 		}
+
+		secrets = append(secrets, secret)
+	}
+
+	// Central tooling finds the kubeconfigs of all tenant clusters in the
+	// mirror namespaces.
+	{
+		namespaces, err := r.mirrorNamespaces(ctx)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		var mirrors []*corev1.Secret
+		for _, n := range namespaces {
+			for _, s := range secrets {
+				if containsSecret(secrets, n, s.Name) || containsSecret(mirrors, n, s.Name) {
+					continue
+				}
+
+				mirrors = append(mirrors, newMirrorSecret(cr, s, n))
+			}
+		}
+
+		secrets = append(secrets, mirrors...)
+	}
+
+	return secrets, nil
+}
+
+func (r *Resource) newRestConfig(ctx context.Context, cr apiv1beta1.Cluster, bd string, v key.KubeConfigVariant) (*rest.Config, error) {
+	tls, err := r.certsSearcher.SearchTLS(ctx, key.ClusterID(&cr), certs.Cert(key.KubeConfigVariantCertificate(v)))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	c := k8srestconfig.Config{
+		Logger: r.logger,
+
+		Address:   key.KubeConfigVariantEndpoint(&cr, bd, v),
+		InCluster: false,
+		TLS: k8srestconfig.ConfigTLS{
+			CAData:  tls.CA,
+			CrtData: tls.Crt,
+			KeyData: tls.Key,
+		},
+	}
+
+	restConfig, err := k8srestconfig.New(c)
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
-	return []*corev1.Secret{secret}, nil
+	return restConfig, nil
 }
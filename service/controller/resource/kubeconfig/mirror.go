@@ -0,0 +1,93 @@
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+)
+
+// mirrorNamespaces returns the existing namespaces kubeconfig Secrets are
+// mirrored to. Terminating namespaces are skipped since no Secrets can be
+// created in them anymore.
+func (r *Resource) mirrorNamespaces(ctx context.Context) ([]string, error) {
+	var namespaces []corev1.Namespace
+
+	if r.mirrorNamespace != "" {
+		ns, err := r.k8sClient.CoreV1().Namespaces().Get(ctx, r.mirrorNamespace, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "not mirroring kubeconfig secrets to namespace %#q", r.mirrorNamespace)
+			r.logger.Debugf(ctx, "namespace %#q does not exist", r.mirrorNamespace)
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			namespaces = append(namespaces, *ns)
+		}
+	}
+
+	if r.mirrorNamespaceSelector != nil {
+		o := metav1.ListOptions{
+			LabelSelector: r.mirrorNamespaceSelector.String(),
+		}
+
+		list, err := r.k8sClient.CoreV1().Namespaces().List(ctx, o)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		namespaces = append(namespaces, list.Items...)
+	}
+
+	seen := map[string]bool{}
+	var names []string
+	for _, ns := range namespaces {
+		if ns.Status.Phase == corev1.NamespaceTerminating || seen[ns.Name] {
+			continue
+		}
+
+		seen[ns.Name] = true
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// newMirrorSecret copies the given kubeconfig Secret into the given namespace.
+// Owner references cannot point across namespaces, so the owning Cluster CR
+// and the mirrored Secret are tracked via annotations. Mirrors keep the
+// cluster and variant labels so that they are found and deleted together with
+// the kubeconfig Secrets of the tenant cluster.
+func newMirrorSecret(cr apiv1beta1.Cluster, secret *corev1.Secret, namespace string) *corev1.Secret {
+	mirror := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				annotation.KubeConfigOwner:  fmt.Sprintf("%s/%s", cr.Namespace, cr.Name),
+				annotation.KubeConfigSource: fmt.Sprintf("%s/%s", secret.Namespace, secret.Name),
+			},
+			Labels: map[string]string{},
+		},
+		Data: map[string][]byte{},
+	}
+
+	for k, v := range secret.Labels {
+		mirror.Labels[k] = v
+	}
+	mirror.Labels[label.KubeConfigMirror] = "true"
+
+	for k, v := range secret.Data {
+		mirror.Data[k] = v
+	}
+
+	return mirror
+}
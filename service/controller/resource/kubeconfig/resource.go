@@ -4,9 +4,10 @@ import (
 	"github.com/giantswarm/certs/v4/pkg/certs"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
-	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
 )
 
@@ -21,7 +22,14 @@ type Config struct {
 	CertsSearcher certs.Interface
 	K8sClient     kubernetes.Interface
 	Logger        micrologger.Logger
-	Tenant        tenantcluster.Interface
+
+	// MirrorNamespace is the namespace all kubeconfig Secrets are mirrored
+	// to. Mirroring into it is disabled when empty.
+	MirrorNamespace string
+	// MirrorNamespaceSelector is the label selector of additional namespaces
+	// all kubeconfig Secrets are mirrored to.
+	MirrorNamespaceSelector string
+	Variants                []key.KubeConfigVariant
 }
 
 // Resource implements the kubeconfig resource.
@@ -30,7 +38,10 @@ type Resource struct {
 	certsSearcher certs.Interface
 	k8sClient     kubernetes.Interface
 	logger        micrologger.Logger
-	tenant        tenantcluster.Interface
+
+	mirrorNamespace         string
+	mirrorNamespaceSelector labels.Selector
+	variants                []key.KubeConfigVariant
 }
 
 // New creates a new configured secret state getter resource managing kube
@@ -50,8 +61,18 @@ func New(config Config) (*Resource, error) {
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
-	if config.Tenant == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.Tenant must not be empty", config)
+
+	if len(config.Variants) == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Variants must not be empty", config)
+	}
+
+	var mirrorNamespaceSelector labels.Selector
+	if config.MirrorNamespaceSelector != "" {
+		var err error
+		mirrorNamespaceSelector, err = labels.Parse(config.MirrorNamespaceSelector)
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "%T.MirrorNamespaceSelector must be a label selector, got %#q", config, config.MirrorNamespaceSelector)
+		}
 	}
 
 	r := &Resource{
@@ -59,7 +80,10 @@ func New(config Config) (*Resource, error) {
 		certsSearcher: config.CertsSearcher,
 		k8sClient:     config.K8sClient,
 		logger:        config.Logger,
-		tenant:        config.Tenant,
+
+		mirrorNamespace:         config.MirrorNamespace,
+		mirrorNamespaceSelector: mirrorNamespaceSelector,
+		variants:                config.Variants,
 	}
 
 	return r, nil
@@ -0,0 +1,357 @@
+package kubeconfig
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/certs/v4/pkg/certstest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+type baseDomain struct{}
+
+func (b baseDomain) BaseDomain(ctx context.Context, obj interface{}) (string, error) {
+	return "example.com", nil
+}
+
+func Test_Resource_GetDesiredState(t *testing.T) {
+	variants, err := key.ParseKubeConfigVariants(`
+- name: admin
+  certificate: app-operator-api
+  secret:
+    name: kubeconfig
+- name: internal
+  endpoint: internal
+  certificate: app-operator-api
+- name: read-only
+  organizations:
+  - giantswarm:read-only
+  secret:
+    namespace: monitoring
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := newTestResource(t, variants)
+
+	secrets, err := r.GetDesiredState(context.Background(), newTestCluster(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		name      string
+		namespace string
+		variant   string
+		server    string
+	}{
+		{
+			name:      "ab12c-kubeconfig",
+			namespace: "ab12c",
+			variant:   "admin",
+			server:    "https://api.ab12c.k8s.example.com",
+		},
+		{
+			name:      "ab12c-kubeconfig-internal",
+			namespace: "ab12c",
+			variant:   "internal",
+			server:    "https://internal-api.ab12c.k8s.example.com",
+		},
+		{
+			name:      "ab12c-kubeconfig-read-only",
+			namespace: "monitoring",
+			variant:   "read-only",
+			server:    "https://api.ab12c.k8s.example.com",
+		},
+	}
+
+	if len(secrets) != len(expected) {
+		t.Fatalf("expected %d secrets, got %d", len(expected), len(secrets))
+	}
+
+	for i, e := range expected {
+		s := secrets[i]
+		if s.Name != e.name || s.Namespace != e.namespace {
+			t.Fatalf("expected secret %s/%s, got %s/%s", e.namespace, e.name, s.Namespace, s.Name)
+		}
+		if s.Labels[label.KubeConfigVariant] != e.variant {
+			t.Fatalf("expected variant label %#q, got %#q", e.variant, s.Labels[label.KubeConfigVariant])
+		}
+		if !strings.Contains(string(s.Data["value"]), e.server) {
+			t.Fatalf("expected kubeconfig of variant %#q to talk to %#q", e.variant, e.server)
+		}
+	}
+}
+
+func Test_Resource_GetDesiredState_Mirror(t *testing.T) {
+	testCases := []struct {
+		name              string
+		mirrorNamespace   string
+		mirrorSelector    string
+		namespaces        []*corev1.Namespace
+		expectedMirrorIDs []string
+	}{
+		{
+			name:              "case 0: mirroring disabled",
+			namespaces:        []*corev1.Namespace{newTestNamespace("giantswarm", nil, false)},
+			expectedMirrorIDs: nil,
+		},
+		{
+			name:              "case 1: mirror namespace does not exist",
+			mirrorNamespace:   "giantswarm",
+			expectedMirrorIDs: nil,
+		},
+		{
+			name:            "case 2: mirror into configured and selected namespaces",
+			mirrorNamespace: "giantswarm",
+			mirrorSelector:  "kubeconfigs=mirror",
+			namespaces: []*corev1.Namespace{
+				newTestNamespace("giantswarm", nil, false),
+				newTestNamespace("tooling", map[string]string{"kubeconfigs": "mirror"}, false),
+				newTestNamespace("terminating", map[string]string{"kubeconfigs": "mirror"}, true),
+				newTestNamespace("other", nil, false),
+			},
+			expectedMirrorIDs: []string{"giantswarm/ab12c-kubeconfig", "tooling/ab12c-kubeconfig"},
+		},
+		{
+			name:            "case 3: configured namespace is selected as well",
+			mirrorNamespace: "giantswarm",
+			mirrorSelector:  "kubeconfigs=mirror",
+			namespaces: []*corev1.Namespace{
+				newTestNamespace("giantswarm", map[string]string{"kubeconfigs": "mirror"}, false),
+			},
+			expectedMirrorIDs: []string{"giantswarm/ab12c-kubeconfig"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := fake.NewSimpleClientset()
+			for _, ns := range tc.namespaces {
+				_, err := k8sClient.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c := newTestConfig(key.DefaultKubeConfigVariants)
+			c.K8sClient = k8sClient
+			c.MirrorNamespace = tc.mirrorNamespace
+			c.MirrorNamespaceSelector = tc.mirrorSelector
+
+			r, err := New(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			secrets, err := r.GetDesiredState(context.Background(), newTestCluster(nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, s := range secrets[1:] {
+				ids = append(ids, s.Namespace+"/"+s.Name)
+
+				if s.Labels[label.KubeConfigMirror] != "true" {
+					t.Fatalf("expected mirror %s/%s to be labelled", s.Namespace, s.Name)
+				}
+				if s.Labels[label.KubeConfigVariant] != "admin" {
+					t.Fatalf("expected mirror %s/%s to keep the variant label", s.Namespace, s.Name)
+				}
+				if s.Annotations[annotation.KubeConfigOwner] != "org-example/ab12c" {
+					t.Fatalf("expected mirror %s/%s to be owned by the cluster, got %#q", s.Namespace, s.Name, s.Annotations[annotation.KubeConfigOwner])
+				}
+				if s.Annotations[annotation.KubeConfigSource] != "ab12c/ab12c-kubeconfig" {
+					t.Fatalf("expected mirror %s/%s to mirror the kubeconfig secret, got %#q", s.Namespace, s.Name, s.Annotations[annotation.KubeConfigSource])
+				}
+				if string(s.Data["value"]) != string(secrets[0].Data["value"]) {
+					t.Fatalf("expected mirror %s/%s to hold the kubeconfig", s.Namespace, s.Name)
+				}
+			}
+
+			if strings.Join(ids, ",") != strings.Join(tc.expectedMirrorIDs, ",") {
+				t.Fatalf("expected mirrors %v, got %v", tc.expectedMirrorIDs, ids)
+			}
+		})
+	}
+}
+
+func Test_Resource_GetCurrentState(t *testing.T) {
+	variants, err := key.ParseKubeConfigVariants(`
+- name: admin
+  certificate: app-operator-api
+  secret:
+    name: kubeconfig
+- name: read-only
+  organizations:
+  - giantswarm:read-only
+  secret:
+    namespace: monitoring
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name        string
+		deletion    bool
+		secrets     []*corev1.Secret
+		expectedIDs []string
+	}{
+		{
+			name:        "case 0: no secrets",
+			expectedIDs: nil,
+		},
+		{
+			name: "case 1: unlabelled secret of configured variant is adopted",
+			secrets: []*corev1.Secret{
+				newTestSecret("ab12c", "ab12c-kubeconfig", ""),
+			},
+			expectedIDs: []string{"ab12c/ab12c-kubeconfig"},
+		},
+		{
+			name: "case 2: secret of removed variant is found",
+			secrets: []*corev1.Secret{
+				newTestSecret("ab12c", "ab12c-kubeconfig", "admin"),
+				newTestSecret("flux", "ab12c-kubeconfig-flux", "flux"),
+				newTestSecret("monitoring", "ab12c-kubeconfig-read-only", "read-only"),
+			},
+			expectedIDs: []string{"ab12c/ab12c-kubeconfig", "flux/ab12c-kubeconfig-flux", "monitoring/ab12c-kubeconfig-read-only"},
+		},
+		{
+			name:     "case 3: secrets outside the cluster namespace are deleted with the cluster",
+			deletion: true,
+			secrets: []*corev1.Secret{
+				newTestSecret("ab12c", "ab12c-kubeconfig", "admin"),
+				newTestSecret("monitoring", "ab12c-kubeconfig-read-only", "read-only"),
+			},
+			expectedIDs: []string{"monitoring/ab12c-kubeconfig-read-only"},
+		},
+		{
+			name:     "case 4: mirrors are deleted with the cluster",
+			deletion: true,
+			secrets: []*corev1.Secret{
+				newTestSecret("ab12c", "ab12c-kubeconfig", "admin"),
+				newTestSecret("giantswarm", "ab12c-kubeconfig", "admin"),
+			},
+			expectedIDs: []string{"giantswarm/ab12c-kubeconfig"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newTestResource(t, variants)
+			for _, s := range tc.secrets {
+				_, err := r.k8sClient.CoreV1().Secrets(s.Namespace).Create(context.Background(), s, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var deletionTimestamp *metav1.Time
+			if tc.deletion {
+				deletionTimestamp = &metav1.Time{}
+			}
+
+			secrets, err := r.GetCurrentState(context.Background(), newTestCluster(deletionTimestamp))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, s := range secrets {
+				ids = append(ids, s.Namespace+"/"+s.Name)
+			}
+			sort.Strings(ids)
+
+			if strings.Join(ids, ",") != strings.Join(tc.expectedIDs, ",") {
+				t.Fatalf("expected secrets %v, got %v", tc.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func newTestCluster(deletionTimestamp *metav1.Time) *apiv1beta1.Cluster {
+	return &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "ab12c",
+			Namespace:         "org-example",
+			DeletionTimestamp: deletionTimestamp,
+			Labels: map[string]string{
+				label.Cluster:      "ab12c",
+				label.Organization: "example",
+			},
+		},
+	}
+}
+
+func newTestConfig(variants []key.KubeConfigVariant) Config {
+	return Config{
+		BaseDomain: baseDomain{},
+		CertsSearcher: certstest.NewSearcher(certstest.Config{
+			TLS: certs.TLS{
+				CA:  []byte("ca"),
+				Crt: []byte("crt"),
+				Key: []byte("key"),
+			},
+		}),
+		K8sClient: fake.NewSimpleClientset(),
+		Logger:    microloggertest.New(),
+
+		Variants: variants,
+	}
+}
+
+func newTestNamespace(name string, labels map[string]string, terminating bool) *corev1.Namespace {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+	if terminating {
+		ns.Status.Phase = corev1.NamespaceTerminating
+	}
+
+	return ns
+}
+
+func newTestResource(t *testing.T, variants []key.KubeConfigVariant) *Resource {
+	r, err := New(newTestConfig(variants))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func newTestSecret(namespace, name, variant string) *corev1.Secret {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				label.Cluster:   "ab12c",
+				label.ManagedBy: "cluster-operator",
+			},
+		},
+	}
+	if variant != "" {
+		s.Labels[label.KubeConfigVariant] = variant
+	}
+
+	return s
+}
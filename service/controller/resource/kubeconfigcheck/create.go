@@ -0,0 +1,154 @@
+package kubeconfigcheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var serverVersion string
+	for _, v := range r.variants {
+		if v.Endpoint != key.KubeConfigEndpointPublic {
+			continue
+		}
+
+		reason, message, version, err := r.check(ctx, cr, v)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if reason != "" {
+			r.logger.Debugf(ctx, "kubeconfig of variant %#q is invalid: %s", v.Name, message)
+
+			err = r.ensureCondition(ctx, cr, func(cl *apiv1beta1.Cluster) {
+				conditions.MarkFalse(cl, key.KubeconfigValidCondition, reason, apiv1beta1.ConditionSeverityWarning, "%s", message)
+			})
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			return nil
+		}
+
+		r.logger.Debugf(ctx, "kubeconfig of variant %#q is valid", v.Name)
+
+		serverVersion = version
+	}
+
+	if serverVersion == "" {
+		r.logger.Debugf(ctx, "no kubeconfig variant using the public endpoint to verify")
+
+		err = r.ensureCondition(ctx, cr, func(cl *apiv1beta1.Cluster) {
+			conditions.Set(cl, conditions.UnknownCondition(key.KubeconfigValidCondition, key.NoPublicVariantReason, "No kubeconfig variant uses the public endpoint."))
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err = r.ensureCondition(ctx, cr, func(cl *apiv1beta1.Cluster) {
+		c := conditions.TrueCondition(key.KubeconfigValidCondition)
+		c.Message = fmt.Sprintf("Tenant API server version %s.", serverVersion)
+		conditions.Set(cl, c)
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// check requests the version of the tenant API using the kubeconfig of the
+// given variant. Failures are returned as condition reason and message since
+// they are reported on the Cluster CR and must not fail the reconciliation.
+func (r *Resource) check(ctx context.Context, cr apiv1beta1.Cluster, v key.KubeConfigVariant) (string, string, string, error) {
+	name := key.KubeConfigVariantSecretName(&cr, v)
+	namespace := key.KubeConfigVariantSecretNamespace(&cr, v)
+
+	var secret corev1.Secret
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret)
+		if apierrors.IsNotFound(err) {
+			return key.KubeconfigNotFoundReason, fmt.Sprintf("Kubeconfig secret %s/%s of variant %s not found.", namespace, name, v.Name), "", nil
+		} else if err != nil {
+			return "", "", "", microerror.Mask(err)
+		}
+	}
+
+	var client discovery.DiscoveryInterface
+	{
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["kubeConfig"])
+		if err != nil {
+			return key.KubeconfigInvalidReason, fmt.Sprintf("Kubeconfig of variant %s cannot be loaded: %s", v.Name, err.Error()), "", nil
+		}
+
+		restConfig.Timeout = r.timeout
+
+		client, err = discovery.NewDiscoveryClientForConfig(restConfig)
+		if err != nil {
+			return key.KubeconfigInvalidReason, fmt.Sprintf("Kubeconfig of variant %s cannot be loaded: %s", v.Name, err.Error()), "", nil
+		}
+	}
+
+	r.logger.Debugf(ctx, "requesting tenant API version using kubeconfig of variant %#q", v.Name)
+
+	info, err := client.ServerVersion()
+	if err != nil {
+		return key.TenantAPIUnreachableReason, fmt.Sprintf("Requesting tenant API version using kubeconfig of variant %s failed: %s", v.Name, err.Error()), "", nil
+	}
+
+	return "", "", info.GitVersion, nil
+}
+
+func (r *Resource) ensureCondition(ctx context.Context, cl apiv1beta1.Cluster, change func(cr *apiv1beta1.Cluster)) error {
+	// Fetch the latest version of the Cluster CR since the one we reconcile
+	// may already be outdated.
+	var cr apiv1beta1.Cluster
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var before apiv1beta1.Condition
+	if c := conditions.Get(&cr, key.KubeconfigValidCondition); c != nil {
+		before = *c
+	}
+
+	change(&cr)
+
+	after := conditions.Get(&cr, key.KubeconfigValidCondition)
+	if after.Status == before.Status && after.Reason == before.Reason && after.Message == before.Message {
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "updating condition %#q of cluster", key.KubeconfigValidCondition)
+
+	err := r.ctrlClient.Status().Update(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "updated condition %#q of cluster", key.KubeconfigValidCondition)
+
+	return nil
+}
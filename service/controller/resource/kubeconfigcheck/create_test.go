@@ -0,0 +1,213 @@
+package kubeconfigcheck
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/kubeconfig/v4"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_KubeconfigCheck_EnsureCreated(t *testing.T) {
+	// The fake tenant API answers version requests. Requests below /hang take
+	// longer than the configured timeout.
+	api := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/version" && req.URL.Path != "/hang/version" {
+			http.NotFound(w, req)
+			return
+		}
+		if strings.HasPrefix(req.URL.Path, "/hang/") {
+			time.Sleep(time.Second)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"24","gitVersion":"v1.24.3"}`))
+	}))
+	defer api.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: api.Certificate().Raw})
+
+	testCases := []struct {
+		name       string
+		kubeConfig *rest.Config
+		rawConfig  []byte
+		variants   []key.KubeConfigVariant
+
+		expectStatus  corev1.ConditionStatus
+		expectReason  string
+		expectMessage string
+	}{
+		{
+			name:          "case 0: kubeconfig secret not found",
+			expectStatus:  corev1.ConditionFalse,
+			expectReason:  key.KubeconfigNotFoundReason,
+			expectMessage: "8y5ck/8y5ck-kubeconfig",
+		},
+		{
+			name:          "case 1: kubeconfig cannot be loaded",
+			rawConfig:     []byte("clusters: ["),
+			expectStatus:  corev1.ConditionFalse,
+			expectReason:  key.KubeconfigInvalidReason,
+			expectMessage: "cannot be loaded",
+		},
+		{
+			name: "case 2: tenant API not trusted",
+			kubeConfig: &rest.Config{
+				Host: api.URL,
+			},
+			expectStatus:  corev1.ConditionFalse,
+			expectReason:  key.TenantAPIUnreachableReason,
+			expectMessage: "certificate",
+		},
+		{
+			name: "case 3: tenant API times out",
+			kubeConfig: &rest.Config{
+				Host: api.URL + "/hang",
+				TLSClientConfig: rest.TLSClientConfig{
+					CAData: ca,
+				},
+			},
+			expectStatus:  corev1.ConditionFalse,
+			expectReason:  key.TenantAPIUnreachableReason,
+			expectMessage: "Requesting tenant API version",
+		},
+		{
+			name: "case 4: kubeconfig is valid",
+			kubeConfig: &rest.Config{
+				Host: api.URL,
+				TLSClientConfig: rest.TLSClientConfig{
+					CAData: ca,
+				},
+			},
+			expectStatus:  corev1.ConditionTrue,
+			expectMessage: "v1.24.3",
+		},
+		{
+			name: "case 5: no variant uses the public endpoint",
+			variants: []key.KubeConfigVariant{
+				{
+					Name:        "internal",
+					Endpoint:    key.KubeConfigEndpointInternal,
+					Certificate: "app-operator-api",
+					Secret: key.KubeConfigVariantSecret{
+						Name: "kubeconfig",
+					},
+				},
+			},
+			expectStatus:  corev1.ConditionUnknown,
+			expectReason:  key.NoPublicVariantReason,
+			expectMessage: "public endpoint",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrlClient := unittest.FakeK8sClient().CtrlClient()
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "8y5ck",
+					Namespace: "org-giantswarm",
+					Labels: map[string]string{
+						label.Cluster: "8y5ck",
+					},
+				},
+			}
+			err := ctrlClient.Create(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			raw := tc.rawConfig
+			if tc.kubeConfig != nil {
+				raw, err = kubeconfig.NewKubeConfigForRESTConfig(ctx, tc.kubeConfig, "giantswarm-8y5ck", "")
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if raw != nil {
+				err = ctrlClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "8y5ck-kubeconfig",
+						Namespace: "8y5ck",
+					},
+					Data: map[string][]byte{
+						"kubeConfig": raw,
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			variants := tc.variants
+			if variants == nil {
+				variants = append(key.DefaultKubeConfigVariants, key.KubeConfigVariant{
+					Name:        "internal",
+					Endpoint:    key.KubeConfigEndpointInternal,
+					Certificate: "app-operator-api",
+					Secret: key.KubeConfigVariantSecret{
+						Name: "kubeconfig-internal",
+					},
+				})
+			}
+
+			var r *Resource
+			{
+				c := Config{
+					CtrlClient: ctrlClient,
+					Logger:     microloggertest.New(),
+
+					Timeout:  200 * time.Millisecond,
+					Variants: variants,
+				}
+
+				r, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = r.EnsureCreated(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var updated apiv1beta1.Cluster
+			err = ctrlClient.Get(ctx, types.NamespacedName{Name: "8y5ck", Namespace: "org-giantswarm"}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := conditions.Get(&updated, key.KubeconfigValidCondition)
+			if c == nil {
+				t.Fatalf("condition == nil, want status %#q", tc.expectStatus)
+			}
+			if c.Status != tc.expectStatus {
+				t.Fatalf("condition status == %#q, want %#q", c.Status, tc.expectStatus)
+			}
+			if c.Reason != tc.expectReason {
+				t.Fatalf("condition reason == %#q, want %#q", c.Reason, tc.expectReason)
+			}
+			if !strings.Contains(c.Message, tc.expectMessage) {
+				t.Fatalf("condition message == %#q, want containing %#q", c.Message, tc.expectMessage)
+			}
+		})
+	}
+}
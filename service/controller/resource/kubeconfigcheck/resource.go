@@ -0,0 +1,68 @@
+package kubeconfigcheck
+
+import (
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+const (
+	Name = "kubeconfigcheck"
+)
+
+type Config struct {
+	CtrlClient ctrlClient.Client
+	Logger     micrologger.Logger
+
+	// Timeout is the timeout of the version request sent to the tenant API.
+	Timeout  time.Duration
+	Variants []key.KubeConfigVariant
+}
+
+// Resource verifies the kubeconfigs generated by the kubeconfig resource. It
+// loads the kubeconfig Secrets of all variants talking to the public API
+// endpoint, requests the version of the tenant API with each of them and
+// reports the result in the KubeconfigValid condition of the Cluster CR.
+// Variants using the internal API endpoint are meant for in-cluster consumers
+// and cannot be verified from the management cluster.
+type Resource struct {
+	ctrlClient ctrlClient.Client
+	logger     micrologger.Logger
+
+	timeout  time.Duration
+	variants []key.KubeConfigVariant
+}
+
+func New(config Config) (*Resource, error) {
+	if config.CtrlClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.Timeout == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Timeout must not be empty", config)
+	}
+	if len(config.Variants) == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Variants must not be empty", config)
+	}
+
+	r := &Resource{
+		ctrlClient: config.CtrlClient,
+		logger:     config.Logger,
+
+		timeout:  config.Timeout,
+		variants: config.Variants,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
@@ -3,6 +3,7 @@ package machinedeploymentstatus
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
@@ -10,14 +11,17 @@ import (
 	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/finalizerskeptcontext"
 	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
 	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
 	"github.com/giantswarm/cluster-operator/v5/pkg/label"
 	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/nodecount"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/tenantclient"
 )
 
@@ -26,17 +30,23 @@ const (
 )
 
 type Config struct {
-	Event     recorder.Interface
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
-	NodeCount nodecount.Interface
+	Event          recorder.Interface
+	K8sClient      k8sclient.Interface
+	Logger         micrologger.Logger
+	NodeCount      nodecount.Interface
+	ReleaseVersion releaseversion.Interface
+
+	Provider string
 }
 
 type Resource struct {
-	event     recorder.Interface
-	k8sClient k8sclient.Interface
-	logger    micrologger.Logger
-	nodeCount nodecount.Interface
+	event          recorder.Interface
+	k8sClient      k8sclient.Interface
+	logger         micrologger.Logger
+	nodeCount      nodecount.Interface
+	releaseVersion releaseversion.Interface
+
+	provider string
 }
 
 func New(config Config) (*Resource, error) {
@@ -52,12 +62,22 @@ func New(config Config) (*Resource, error) {
 	if config.NodeCount == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NodeCount must not be empty", config)
 	}
+	if config.ReleaseVersion == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ReleaseVersion must not be empty", config)
+	}
+
+	if config.Provider == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
+	}
 
 	r := &Resource{
-		event:     config.Event,
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
-		nodeCount: config.NodeCount,
+		event:          config.Event,
+		k8sClient:      config.K8sClient,
+		logger:         config.Logger,
+		nodeCount:      config.NodeCount,
+		releaseVersion: config.ReleaseVersion,
+
+		provider: config.Provider,
 	}
 
 	return r, nil
@@ -100,6 +120,12 @@ func (r *Resource) ensure(ctx context.Context, obj interface{}) error {
 	} else if err != nil {
 		return microerror.Mask(err)
 	}
+
+	err = r.ensureVersionAnnotations(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	{
 		r.logger.Debugf(ctx, "checking if status of machine deployment needs to be updated")
 
@@ -143,3 +169,76 @@ func (r *Resource) ensure(ctx context.Context, obj interface{}) error {
 
 	return nil
 }
+
+// ensureVersionAnnotations writes the number of worker nodes of the node pool
+// on the desired provider operator version and on older versions into the
+// annotations of the MachineDeployment CR, so that the upgrade progress of
+// every node pool is visible and can be exported as metric.
+func (r *Resource) ensureVersionAnnotations(ctx context.Context, cr *apiv1beta1.MachineDeployment) error {
+	if key.IsDeleted(cr) {
+		return nil
+	}
+
+	providerOperator := fmt.Sprintf("%s-operator", r.provider)
+
+	var desiredVersion string
+	{
+		componentVersions, err := r.releaseVersion.ComponentVersion(ctx, cr)
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "release %#q not found", key.ReleaseVersion(cr))
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		desiredVersion = componentVersions[providerOperator].Version
+		if desiredVersion == "" {
+			r.logger.Debugf(ctx, "component version not found for %#q", providerOperator)
+			return nil
+		}
+	}
+
+	versionLabel := fmt.Sprintf("%s.giantswarm.io/version", providerOperator)
+
+	versionCount, err := r.nodeCount.WorkerVersionCount(ctx, cr, versionLabel, desiredVersion)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	count := versionCount[key.MachineDeployment(cr)]
+
+	desired := map[string]string{
+		annotation.NodePoolDesiredVersion: desiredVersion,
+		annotation.NodePoolOutdatedNodes:  strconv.Itoa(int(count.Outdated)),
+		annotation.NodePoolUpgradedNodes:  strconv.Itoa(int(count.Upgraded)),
+	}
+
+	var changed bool
+	for k, v := range desired {
+		if cr.Annotations[k] != v {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+	for k, v := range desired {
+		cr.Annotations[k] = v
+	}
+
+	r.logger.Debugf(ctx, "updating node versions of machine deployment, %d of %d nodes on version %#q", count.Upgraded, count.Upgraded+count.Outdated, desiredVersion)
+
+	err = r.k8sClient.CtrlClient().Update(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "updated node versions of machine deployment")
+
+	return nil
+}
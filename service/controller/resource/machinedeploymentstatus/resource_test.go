@@ -0,0 +1,184 @@
+package machinedeploymentstatus
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/nodecount"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+type fakeNodeCount struct {
+	versionLabel string
+	version      string
+	count        nodecount.NodeVersion
+}
+
+func (f *fakeNodeCount) MasterCount(ctx context.Context, obj interface{}) (map[string]nodecount.Node, error) {
+	return nil, nil
+}
+
+func (f *fakeNodeCount) WorkerCount(ctx context.Context, obj interface{}) (map[string]nodecount.Node, error) {
+	return nil, nil
+}
+
+func (f *fakeNodeCount) WorkerVersionCount(ctx context.Context, obj interface{}, versionLabel string, version string) (map[string]nodecount.NodeVersion, error) {
+	f.versionLabel = versionLabel
+	f.version = version
+
+	return map[string]nodecount.NodeVersion{
+		"a1b2c": f.count,
+	}, nil
+}
+
+type fakeReleaseVersion struct {
+	awsOperatorVersion string
+}
+
+func (f fakeReleaseVersion) Apps(ctx context.Context, obj interface{}) (map[string]releaseversion.ReleaseApp, error) {
+	return nil, nil
+}
+
+func (f fakeReleaseVersion) ComponentVersion(ctx context.Context, obj interface{}) (map[string]releaseversion.ReleaseComponent, error) {
+	return map[string]releaseversion.ReleaseComponent{
+		"aws-operator": {Version: f.awsOperatorVersion},
+	}, nil
+}
+
+func Test_Resource_ensureVersionAnnotations(t *testing.T) {
+	testCases := []struct {
+		name               string
+		annotations        map[string]string
+		awsOperatorVersion string
+		count              nodecount.NodeVersion
+
+		expectAnnotations map[string]string
+		expectUpdated     bool
+	}{
+		{
+			name:               "case 0: node versions are written",
+			awsOperatorVersion: "14.1.0",
+			count:              nodecount.NodeVersion{Upgraded: 2, Outdated: 1},
+			expectAnnotations: map[string]string{
+				annotation.NodePoolDesiredVersion: "14.1.0",
+				annotation.NodePoolOutdatedNodes:  "1",
+				annotation.NodePoolUpgradedNodes:  "2",
+			},
+			expectUpdated: true,
+		},
+		{
+			name: "case 1: unchanged node versions are not written",
+			annotations: map[string]string{
+				annotation.NodePoolDesiredVersion: "14.1.0",
+				annotation.NodePoolOutdatedNodes:  "0",
+				annotation.NodePoolUpgradedNodes:  "3",
+			},
+			awsOperatorVersion: "14.1.0",
+			count:              nodecount.NodeVersion{Upgraded: 3, Outdated: 0},
+			expectAnnotations: map[string]string{
+				annotation.NodePoolDesiredVersion: "14.1.0",
+				annotation.NodePoolOutdatedNodes:  "0",
+				annotation.NodePoolUpgradedNodes:  "3",
+			},
+		},
+		{
+			name: "case 2: new desired version is written",
+			annotations: map[string]string{
+				annotation.NodePoolDesiredVersion: "14.0.0",
+				annotation.NodePoolOutdatedNodes:  "0",
+				annotation.NodePoolUpgradedNodes:  "3",
+			},
+			awsOperatorVersion: "14.1.0",
+			count:              nodecount.NodeVersion{Upgraded: 0, Outdated: 3},
+			expectAnnotations: map[string]string{
+				annotation.NodePoolDesiredVersion: "14.1.0",
+				annotation.NodePoolOutdatedNodes:  "3",
+				annotation.NodePoolUpgradedNodes:  "0",
+			},
+			expectUpdated: true,
+		},
+		{
+			name:               "case 3: nothing is written without provider operator version",
+			awsOperatorVersion: "",
+			count:              nodecount.NodeVersion{Upgraded: 3, Outdated: 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := unittest.FakeK8sClient()
+
+			md := &apiv1beta1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "a1b2c",
+					Namespace:   "org-giantswarm",
+					Annotations: tc.annotations,
+					Labels: map[string]string{
+						label.Cluster:           "8y5ck",
+						label.MachineDeployment: "a1b2c",
+					},
+				},
+			}
+			err := k8sClient.CtrlClient().Create(ctx, md)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resourceVersion := md.ResourceVersion
+
+			nodeCount := &fakeNodeCount{count: tc.count}
+
+			r := &Resource{
+				k8sClient:      k8sClient,
+				logger:         microloggertest.New(),
+				nodeCount:      nodeCount,
+				releaseVersion: fakeReleaseVersion{awsOperatorVersion: tc.awsOperatorVersion},
+
+				provider: label.ProviderAWS,
+			}
+
+			err = r.ensureVersionAnnotations(ctx, md)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var actual apiv1beta1.MachineDeployment
+			err = k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: md.Name, Namespace: md.Namespace}, &actual)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if updated := actual.ResourceVersion != resourceVersion; updated != tc.expectUpdated {
+				t.Fatalf("updated == %t, want %t", updated, tc.expectUpdated)
+			}
+
+			if tc.awsOperatorVersion != "" {
+				if nodeCount.versionLabel != "aws-operator.giantswarm.io/version" || nodeCount.version != tc.awsOperatorVersion {
+					t.Fatalf("counted nodes with %#q == %#q, want %#q == %#q", nodeCount.versionLabel, nodeCount.version, "aws-operator.giantswarm.io/version", tc.awsOperatorVersion)
+				}
+			}
+
+			annotations := map[string]string{}
+			for _, k := range []string{annotation.NodePoolDesiredVersion, annotation.NodePoolOutdatedNodes, annotation.NodePoolUpgradedNodes} {
+				if v, ok := actual.Annotations[k]; ok {
+					annotations[k] = v
+				}
+			}
+			if tc.expectAnnotations == nil {
+				tc.expectAnnotations = map[string]string{}
+			}
+			if !reflect.DeepEqual(annotations, tc.expectAnnotations) {
+				t.Fatalf("annotations == %#v, want %#v", annotations, tc.expectAnnotations)
+			}
+		})
+	}
+}
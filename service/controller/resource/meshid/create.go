@@ -0,0 +1,100 @@
+package meshid
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/meshid"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !key.CiliumClusterMeshIDRequired(cr) {
+		r.logger.Debugf(ctx, "cluster does not require a cilium cluster mesh id")
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "ensuring cilium cluster mesh id")
+
+	// Failed allocations are reported in a condition of the Cluster CR
+	// instead of failing the reconciliation, so that all other resources keep
+	// reconciling the cluster. The cilium values are rendered without ID in
+	// the meantime.
+	id, err := r.meshID.ID(ctx, &cr)
+	if meshid.IsExhausted(err) {
+		r.logger.Debugf(ctx, "did not ensure cilium cluster mesh id: %s", microerror.Pretty(err, false))
+		return r.ensureCondition(ctx, cr, key.MeshIDsExhaustedReason, microerror.Pretty(err, false))
+	} else if meshid.IsConflict(err) {
+		r.logger.Debugf(ctx, "did not ensure cilium cluster mesh id: %s", microerror.Pretty(err, false))
+		return r.ensureCondition(ctx, cr, key.MeshIDConflictReason, microerror.Pretty(err, false))
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "ensured cilium cluster mesh id %d", id)
+
+	err = r.ensureCondition(ctx, cr, "", "")
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// The cluster values are rendered based on the mesh ID annotation of the
+	// reconciled Cluster CR. In case the allocation changed the annotation we
+	// start over so that all further resources see the allocated ID.
+	if current, ok := key.CiliumClusterMeshID(&cr); !ok || current != id {
+		r.logger.Debugf(ctx, "canceling reconciliation")
+		reconciliationcanceledcontext.SetCanceled(ctx)
+	}
+
+	return nil
+}
+
+// ensureCondition sets the CiliumClusterMeshIDAllocated condition of the
+// Cluster CR. An empty reason marks the condition True.
+func (r *Resource) ensureCondition(ctx context.Context, cl apiv1beta1.Cluster, reason, message string) error {
+	// Fetch the latest version of the Cluster CR since the allocation may
+	// have updated its annotations.
+	var cr apiv1beta1.Cluster
+	{
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: cl.GetName(), Namespace: cl.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	current := conditions.Get(&cr, key.CiliumClusterMeshIDAllocatedCondition)
+
+	if reason == "" {
+		if current != nil && current.Status == corev1.ConditionTrue {
+			return nil
+		}
+		conditions.MarkTrue(&cr, key.CiliumClusterMeshIDAllocatedCondition)
+	} else {
+		if current != nil && current.Status == corev1.ConditionFalse && current.Reason == reason && current.Message == message {
+			return nil
+		}
+		conditions.MarkFalse(&cr, key.CiliumClusterMeshIDAllocatedCondition, reason, apiv1beta1.ConditionSeverityWarning, "%s", message)
+	}
+
+	r.logger.Debugf(ctx, "updating condition %#q of cluster", key.CiliumClusterMeshIDAllocatedCondition)
+
+	err := r.ctrlClient.Status().Update(ctx, &cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "updated condition %#q of cluster", key.CiliumClusterMeshIDAllocatedCondition)
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package meshid
+
+import (
+	"context"
+	"testing"
+
+	k8smetadataannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/meshid"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_MeshID_EnsureCreated(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		allocations map[string]string
+
+		expectCondition bool
+		expectStatus    corev1.ConditionStatus
+		expectReason    string
+	}{
+		{
+			name:            "case 0: cluster without cluster mesh gets no ID",
+			expectCondition: false,
+		},
+		{
+			name: "case 1: cluster with cluster mesh gets an ID",
+			annotations: map[string]string{
+				annotation.CiliumClusterMeshEnabled: "true",
+			},
+			expectCondition: true,
+			expectStatus:    corev1.ConditionTrue,
+		},
+		{
+			name: "case 2: cluster with Cilium ENI mode but without cluster mesh gets no ID",
+			annotations: map[string]string{
+				k8smetadataannotation.CiliumIpamModeAnnotation: k8smetadataannotation.CiliumIpamModeENI,
+			},
+			expectCondition: false,
+		},
+		{
+			name: "case 3: exhausted range is reported in a condition",
+			annotations: map[string]string{
+				annotation.CiliumClusterMeshEnabled: "true",
+			},
+			allocations: map[string]string{
+				"1": "org-giantswarm/al9qy",
+			},
+			expectCondition: true,
+			expectStatus:    corev1.ConditionFalse,
+			expectReason:    key.MeshIDsExhaustedReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := unittest.FakeK8sClient()
+
+			cluster := &apiv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "8y5ck",
+					Namespace:   "org-giantswarm",
+					Annotations: tc.annotations,
+				},
+			}
+			objects := []client.Object{cluster}
+			if tc.allocations != nil {
+				objects = append(objects, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      meshid.ConfigMapName,
+						Namespace: "giantswarm",
+					},
+					Data: tc.allocations,
+				})
+			}
+			for _, o := range objects {
+				err := k8sClient.CtrlClient().Create(ctx, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var r *Resource
+			{
+				m, err := meshid.New(meshid.Config{K8sClient: k8sClient, Max: 1, Min: 1, Namespace: "giantswarm"})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				c := Config{
+					CtrlClient: k8sClient.CtrlClient(),
+					Logger:     microloggertest.New(),
+					MeshID:     m,
+				}
+
+				r, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := r.EnsureCreated(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var updated apiv1beta1.Cluster
+			err = k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := conditions.Get(&updated, key.CiliumClusterMeshIDAllocatedCondition)
+			if !tc.expectCondition {
+				if c != nil {
+					t.Fatalf("condition %#q == %#v, want nil", key.CiliumClusterMeshIDAllocatedCondition, c)
+				}
+
+				err = k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: meshid.ConfigMapName, Namespace: "giantswarm"}, &corev1.ConfigMap{})
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("error == %#v, want not found", err)
+				}
+				return
+			}
+
+			if c == nil || c.Status != tc.expectStatus || c.Reason != tc.expectReason {
+				t.Fatalf("condition %#q == %#v, want status %#q and reason %#q", key.CiliumClusterMeshIDAllocatedCondition, c, tc.expectStatus, tc.expectReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package meshid
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	r.logger.Debugf(ctx, "releasing cilium cluster mesh id")
+
+	err := r.meshID.Release(ctx, obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "released cilium cluster mesh id")
+
+	return nil
+}
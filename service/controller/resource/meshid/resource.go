@@ -0,0 +1,53 @@
+package meshid
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/service/internal/meshid"
+)
+
+const (
+	Name = "meshid"
+)
+
+type Config struct {
+	CtrlClient ctrlClient.Client
+	Logger     micrologger.Logger
+	MeshID     meshid.Interface
+}
+
+// Resource allocates the Cilium cluster mesh ID of tenant clusters requiring
+// one and frees it again once the tenant cluster gets deleted. The result of
+// the allocation is reported in the CiliumClusterMeshIDAllocated condition of
+// the Cluster CR.
+type Resource struct {
+	ctrlClient ctrlClient.Client
+	logger     micrologger.Logger
+	meshID     meshid.Interface
+}
+
+func New(config Config) (*Resource, error) {
+	if config.CtrlClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.MeshID == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.MeshID must not be empty", config)
+	}
+
+	r := &Resource{
+		ctrlClient: config.CtrlClient,
+		logger:     config.Logger,
+		meshID:     config.MeshID,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
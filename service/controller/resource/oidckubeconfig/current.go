@@ -0,0 +1,46 @@
+package oidckubeconfig
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+func (r *Resource) GetCurrentState(ctx context.Context, obj interface{}) ([]*corev1.ConfigMap, error) {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// The config maps are deleted when the namespace is deleted.
+	if key.IsDeleted(&cr) {
+		r.logger.Debugf(ctx, "not deleting oidc kubeconfig config map for tenant cluster %#q", key.ClusterID(&cr))
+		r.logger.Debugf(ctx, "canceling resource")
+		resourcecanceledcontext.SetCanceled(ctx)
+		return nil, nil
+	}
+
+	var configMap *corev1.ConfigMap
+	{
+		r.logger.Debugf(ctx, "finding config map %#q for tenant cluster %#q", key.OIDCKubeConfigConfigMapName(&cr), key.ClusterID(&cr))
+
+		configMap, err = r.k8sClient.CoreV1().ConfigMaps(key.ClusterID(&cr)).Get(ctx, key.OIDCKubeConfigConfigMapName(&cr), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find config map %#q for tenant cluster %#q", key.OIDCKubeConfigConfigMapName(&cr), key.ClusterID(&cr))
+			return nil, nil
+
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "found config map %#q for tenant cluster %#q", key.OIDCKubeConfigConfigMapName(&cr), key.ClusterID(&cr))
+	}
+
+	return []*corev1.ConfigMap{configMap}, nil
+}
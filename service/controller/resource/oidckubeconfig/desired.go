@@ -0,0 +1,122 @@
+package oidckubeconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v8/pkg/controller/context/resourcecanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*corev1.ConfigMap, error) {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if r.issuerURL == "" {
+		r.logger.Debugf(ctx, "oidc kubeconfig disabled for the installation")
+		return nil, nil
+	}
+
+	bd, err := r.baseDomain.BaseDomain(ctx, &cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// The CA of the tenant cluster is taken from the API certificate. The
+	// private key found along with it is not used.
+	var ca []byte
+	{
+		tls, err := r.certsSearcher.SearchTLS(ctx, key.ClusterID(&cr), certs.APICert)
+		if certs.IsTimeout(err) {
+			r.logger.Debugf(ctx, "timeout fetching certificates")
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+			return nil, nil
+
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		ca = tls.CA
+	}
+
+	b, err := r.newKubeConfig(cr, bd, ca)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.OIDCKubeConfigConfigMapName(&cr),
+			Namespace: key.ClusterID(&cr),
+			Labels: map[string]string{
+				label.Cluster:       key.ClusterID(&cr),
+				label.ConfigMapType: label.ConfigMapTypeKubeConfig,
+				label.ManagedBy:     project.Name(),
+				label.Organization:  key.OrganizationID(&cr),
+				label.ServiceType:   label.ServiceTypeManaged,
+			},
+		},
+		Data: map[string]string{
+			// used by legacy Giant Swarm tooling
+			"kubeConfig": string(b),
+			// de-facto industry standard
+			"value": string(b),
+		},
+	}
+
+	return []*corev1.ConfigMap{configMap}, nil
+}
+
+// newKubeConfig renders a kubeconfig without credentials. Tokens are obtained
+// from the OIDC issuer of the installation by the kubectl oidc-login exec
+// plugin when the kubeconfig is used.
+//
+//	https://github.com/int128/kubelogin
+func (r *Resource) newKubeConfig(cr apiv1beta1.Cluster, bd string, ca []byte) ([]byte, error) {
+	name := key.KubeConfigClusterName(&cr)
+	user := fmt.Sprintf("%s-oidc", name)
+
+	c := clientcmdapi.NewConfig()
+	c.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   key.KubeConfigEndpoint(&cr, bd),
+		CertificateAuthorityData: ca,
+	}
+	c.AuthInfos[user] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion: "client.authentication.k8s.io/v1beta1",
+			Command:    "kubectl",
+			Args: []string{
+				"oidc-login",
+				"get-token",
+				fmt.Sprintf("--oidc-issuer-url=%s", r.issuerURL),
+				fmt.Sprintf("--oidc-client-id=%s", r.clientID),
+			},
+			InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+		},
+	}
+	c.Contexts[name] = &clientcmdapi.Context{
+		Cluster:  name,
+		AuthInfo: user,
+	}
+	c.CurrentContext = name
+
+	b, err := clientcmd.Write(*c)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,174 @@
+package oidckubeconfig
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/certs/v4/pkg/certstest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+)
+
+type baseDomain struct{}
+
+func (b baseDomain) BaseDomain(ctx context.Context, obj interface{}) (string, error) {
+	return "example.com", nil
+}
+
+func Test_Resource_New(t *testing.T) {
+	testCases := []struct {
+		name         string
+		clientID     string
+		issuerURL    string
+		errorMatcher func(error) bool
+	}{
+		{
+			name: "case 0: disabled",
+		},
+		{
+			name:      "case 1: enabled",
+			clientID:  "kubernetes",
+			issuerURL: "https://dex.example.com",
+		},
+		{
+			name:         "case 2: error, client ID missing",
+			issuerURL:    "https://dex.example.com",
+			errorMatcher: IsInvalidConfigError,
+		},
+		{
+			name:         "case 3: error, issuer URL without https",
+			clientID:     "kubernetes",
+			issuerURL:    "http://dex.example.com",
+			errorMatcher: IsInvalidConfigError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestConfig()
+			c.ClientID = tc.clientID
+			c.IssuerURL = tc.issuerURL
+
+			_, err := New(c)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func Test_Resource_GetDesiredState(t *testing.T) {
+	cluster := &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ab12c",
+			Namespace: "org-example",
+			Labels: map[string]string{
+				label.Cluster:      "ab12c",
+				label.Organization: "example",
+			},
+		},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		r, err := New(newTestConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		configMaps, err := r.GetDesiredState(context.Background(), cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(configMaps) != 0 {
+			t.Fatalf("expected no config maps, got %d", len(configMaps))
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		c := newTestConfig()
+		c.ClientID = "kubernetes"
+		c.IssuerURL = "https://dex.example.com"
+
+		r, err := New(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		configMaps, err := r.GetDesiredState(context.Background(), cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(configMaps) != 1 {
+			t.Fatalf("expected 1 config map, got %d", len(configMaps))
+		}
+
+		cm := configMaps[0]
+		if cm.Name != "ab12c-oidc-kubeconfig" || cm.Namespace != "ab12c" {
+			t.Fatalf("expected config map ab12c/ab12c-oidc-kubeconfig, got %s/%s", cm.Namespace, cm.Name)
+		}
+		if cm.Labels[label.ConfigMapType] != label.ConfigMapTypeKubeConfig {
+			t.Fatalf("expected config map type %#q, got %#q", label.ConfigMapTypeKubeConfig, cm.Labels[label.ConfigMapType])
+		}
+
+		kubeConfig, err := clientcmd.Load([]byte(cm.Data["value"]))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cluster := kubeConfig.Clusters[kubeConfig.Contexts[kubeConfig.CurrentContext].Cluster]
+		if cluster.Server != "https://api.ab12c.k8s.example.com" {
+			t.Fatalf("expected server %#q, got %#q", "https://api.ab12c.k8s.example.com", cluster.Server)
+		}
+		if string(cluster.CertificateAuthorityData) != "ca" {
+			t.Fatalf("expected CA of the tenant cluster, got %#q", cluster.CertificateAuthorityData)
+		}
+
+		user := kubeConfig.AuthInfos[kubeConfig.Contexts[kubeConfig.CurrentContext].AuthInfo]
+		if len(user.ClientCertificateData) != 0 || len(user.ClientKeyData) != 0 || user.Token != "" || user.Password != "" {
+			t.Fatalf("expected kubeconfig without credentials")
+		}
+		if user.Exec == nil {
+			t.Fatalf("expected exec plugin")
+		}
+
+		expectedArgs := []string{
+			"oidc-login",
+			"get-token",
+			"--oidc-issuer-url=https://dex.example.com",
+			"--oidc-client-id=kubernetes",
+		}
+		if user.Exec.Command != "kubectl" || !reflect.DeepEqual(user.Exec.Args, expectedArgs) {
+			t.Fatalf("expected exec plugin %#q %#v, got %#q %#v", "kubectl", expectedArgs, user.Exec.Command, user.Exec.Args)
+		}
+	})
+}
+
+func newTestConfig() Config {
+	return Config{
+		BaseDomain: baseDomain{},
+		CertsSearcher: certstest.NewSearcher(certstest.Config{
+			TLS: certs.TLS{
+				CA:  []byte("ca"),
+				Crt: []byte("crt"),
+				Key: []byte("key"),
+			},
+		}),
+		K8sClient: fake.NewSimpleClientset(),
+		Logger:    microloggertest.New(),
+	}
+}
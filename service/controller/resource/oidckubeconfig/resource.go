@@ -0,0 +1,86 @@
+package oidckubeconfig
+
+import (
+	"net/url"
+
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
+)
+
+const (
+	// Name is the identifier of the resource.
+	Name = "oidckubeconfig"
+)
+
+// Config represents the configuration used to create a new OIDC kubeconfig
+// resource.
+type Config struct {
+	BaseDomain    basedomain.Interface
+	CertsSearcher certs.Interface
+	K8sClient     kubernetes.Interface
+	Logger        micrologger.Logger
+
+	// ClientID is the OIDC client ID of the installation.
+	ClientID string
+	// IssuerURL is the URL of the OIDC issuer of the installation. OIDC
+	// kubeconfigs are not generated when empty.
+	IssuerURL string
+}
+
+// Resource implements the OIDC kubeconfig resource.
+type Resource struct {
+	baseDomain    basedomain.Interface
+	certsSearcher certs.Interface
+	k8sClient     kubernetes.Interface
+	logger        micrologger.Logger
+
+	clientID  string
+	issuerURL string
+}
+
+// New creates a new configured config map state getter resource managing OIDC
+// kubeconfigs for human users. OIDC kubeconfigs contain no credentials, which
+// is why they are stored in config maps. Tokens are obtained by the kubectl
+// oidc-login exec plugin.
+//
+//	https://pkg.go.dev/github.com/giantswarm/operatorkit/v8/pkg/resource/k8s/configmapresource#StateGetter
+func New(config Config) (*Resource, error) {
+	if config.BaseDomain == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.BaseDomain must not be empty", config)
+	}
+	if config.CertsSearcher == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CertsSearcher must not be empty", config)
+	}
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.IssuerURL != "" {
+		u, err := url.Parse(config.IssuerURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, microerror.Maskf(invalidConfigError, "%T.IssuerURL must be an https URL, got %#q", config, config.IssuerURL)
+		}
+		if config.ClientID == "" {
+			return nil, microerror.Maskf(invalidConfigError, "%T.ClientID must not be empty when %T.IssuerURL is set", config, config)
+		}
+	}
+
+	r := &Resource{
+		baseDomain:    config.BaseDomain,
+		certsSearcher: config.CertsSearcher,
+		k8sClient:     config.K8sClient,
+		logger:        config.Logger,
+
+		clientID:  config.ClientID,
+		issuerURL: config.IssuerURL,
+	}
+
+	return r, nil
+}
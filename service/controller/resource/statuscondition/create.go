@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	"github.com/giantswarm/errors/tenant"
@@ -13,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/cluster-operator/v5/pkg/label"
@@ -123,11 +127,24 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		r.logger.Debugf(ctx, "found %d MachineDeployments for tenant cluster", len(mdList.Items))
 	}
 
-	err = r.computeClusterStatusConditions(ctx, cl, uc, nodes, cpList.Items, mdList.Items)
+	original := cl.DeepCopy()
+
+	err = r.computeClusterStatusConditions(ctx, &cl, uc, nodes, cpList.Items, mdList.Items)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
+	if !reflect.DeepEqual(original.GetConditions(), cl.GetConditions()) {
+		r.logger.Debugf(ctx, "updating cluster conditions")
+
+		err := r.k8sClient.CtrlClient().Status().Update(ctx, &cl)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated cluster conditions")
+	}
+
 	if !reflect.DeepEqual(cr.GetCommonClusterStatus(), uc.GetCommonClusterStatus()) {
 		r.logger.Debugf(ctx, "updating cluster status")
 
@@ -147,16 +164,17 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	return nil
 }
 
-func (r *Resource) computeClusterStatusConditions(ctx context.Context, cl apiv1beta1.Cluster, cr infrastructurev1alpha3.CommonClusterObject, nodes []corev1.Node, controlPlanes []infrastructurev1alpha3.G8sControlPlane, machineDeployments []apiv1beta1.MachineDeployment) error {
+func (r *Resource) computeClusterStatusConditions(ctx context.Context, cl *apiv1beta1.Cluster, cr infrastructurev1alpha3.CommonClusterObject, nodes []corev1.Node, controlPlanes []infrastructurev1alpha3.G8sControlPlane, machineDeployments []apiv1beta1.MachineDeployment) error {
 	var desiredVersion string
 	var nodesReady bool
 
+	providerOperatorVersionLabel := fmt.Sprintf("%s-operator.giantswarm.io/version", r.provider)
+
 	desiredVersion, err := r.getDesiredVersion(ctx, cr)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 	{
-		providerOperatorVersionLabel := fmt.Sprintf("%s-operator.giantswarm.io/version", r.provider)
 		sameVersion := allNodesHaveVersion(nodes, desiredVersion, providerOperatorVersionLabel)
 		sameMasterCount := allMasterNodesReady(controlPlanes)
 		sameWorkerCount := allWorkerNodesReady(machineDeployments)
@@ -164,7 +182,67 @@ func (r *Resource) computeClusterStatusConditions(ctx context.Context, cl apiv1b
 		nodesReady = sameMasterCount && sameWorkerCount && sameVersion
 	}
 
-	return r.writeClusterStatusConditions(ctx, cl, cr, nodesReady, desiredVersion)
+	err = r.writeClusterStatusConditions(ctx, *cl, cr, nodesReady, desiredVersion)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	lagging := laggingNodeGroups(nodes, desiredVersion, providerOperatorVersionLabel)
+	r.writeStuckConditions(ctx, cl, cr.GetCommonClusterStatus(), lagging, desiredVersion)
+
+	return nil
+}
+
+// writeStuckConditions reports creations and upgrades taking longer than the
+// configured thresholds in the CreationStuck and UpgradeStuck conditions of
+// the Cluster CR. Both conditions are removed again once the transition
+// finished. Warning events are emitted when a transition becomes stuck.
+func (r *Resource) writeStuckConditions(ctx context.Context, cl *apiv1beta1.Cluster, status infrastructurev1alpha3.CommonClusterStatus, lagging []string, desiredVersion string) {
+	{
+		var since time.Time
+		if status.HasCreatingCondition() && !status.HasCreatedCondition() {
+			since = status.GetCreatingCondition().LastTransitionTime.Time
+		}
+
+		r.writeStuckCondition(ctx, cl, key.CreationStuckCondition, key.CreationTimeoutReason, "ClusterCreationStuck", "creation", since, r.creationStuckThreshold, lagging, desiredVersion)
+	}
+
+	{
+		var since time.Time
+		if status.LatestCondition() == infrastructurev1alpha3.ClusterStatusConditionUpdating {
+			since = status.GetUpdatingCondition().LastTransitionTime.Time
+		}
+
+		r.writeStuckCondition(ctx, cl, key.UpgradeStuckCondition, key.UpgradeTimeoutReason, "ClusterUpgradeStuck", "upgrade", since, r.upgradeStuckThreshold, lagging, desiredVersion)
+	}
+}
+
+func (r *Resource) writeStuckCondition(ctx context.Context, cl *apiv1beta1.Cluster, t apiv1beta1.ConditionType, reason string, eventReason string, transition string, since time.Time, threshold time.Duration, lagging []string, desiredVersion string) {
+	if since.IsZero() || time.Since(since) < threshold {
+		if conditions.Has(cl, t) {
+			r.logger.Debugf(ctx, "removing %#q condition", t)
+			conditions.Delete(cl, t)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("Cluster %s started at %s and did not finish within %s.", transition, since.UTC().Format(time.RFC3339), threshold)
+	if len(lagging) > 0 {
+		message += fmt.Sprintf(" Nodes of %s do not have version %s.", strings.Join(lagging, ", "), desiredVersion)
+	}
+
+	if !conditions.IsTrue(cl, t) {
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("setting %#q condition", t))
+		r.event.Warn(ctx, cl, eventReason, message)
+	}
+
+	conditions.Set(cl, &apiv1beta1.Condition{
+		Type:     t,
+		Status:   corev1.ConditionTrue,
+		Severity: apiv1beta1.ConditionSeverityWarning,
+		Reason:   reason,
+		Message:  message,
+	})
 }
 
 func (r *Resource) writeClusterStatusConditions(ctx context.Context, cl apiv1beta1.Cluster, cr infrastructurev1alpha3.CommonClusterObject, nodesReady bool, desiredVersion string) error {
@@ -268,6 +346,36 @@ func allWorkerNodesReady(machineDeployments []apiv1beta1.MachineDeployment) bool
 	return readyWorkerReplicas == desiredWorkerReplicas
 }
 
+// laggingNodeGroups returns the control planes and node pools having nodes
+// which do not carry the desired provider operator version label yet.
+func laggingNodeGroups(nodes []corev1.Node, version string, providerOperatorVersionLabel string) []string {
+	seen := map[string]bool{}
+	for _, n := range nodes {
+		if n.Labels[providerOperatorVersionLabel] == version {
+			continue
+		}
+
+		var group string
+		if id := n.Labels[label.ControlPlane]; id != "" {
+			group = fmt.Sprintf("control plane %s", id)
+		} else if id := n.Labels[label.MachineDeployment]; id != "" {
+			group = fmt.Sprintf("node pool %s", id)
+		} else {
+			group = fmt.Sprintf("node %s", n.Name)
+		}
+
+		seen[group] = true
+	}
+
+	var groups []string
+	for g := range seen {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+
+	return groups
+}
+
 func allNodesHaveVersion(nodes []corev1.Node, version string, providerOperatorVersionLabel string) bool {
 	if len(nodes) == 0 {
 		return false
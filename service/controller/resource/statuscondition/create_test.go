@@ -2,14 +2,22 @@ package statuscondition
 
 import (
 	"context"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
 	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
 )
@@ -763,3 +771,145 @@ func TestComputeVersionChange(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteStuckConditions(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		conditions []infrastructurev1alpha3.CommonClusterStatusCondition
+		stuck      []apiv1beta1.ConditionType
+		lagging    []string
+
+		expectConditions []apiv1beta1.ConditionType
+		expectMessage    string
+	}{
+		// The cluster is creating within the threshold
+		{
+			name: "case 0",
+
+			conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+				unittest.GetCreatingCondition(20),
+			},
+		},
+		// The cluster is creating for longer than the threshold
+		{
+			name: "case 1",
+
+			conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+				unittest.GetCreatingCondition(40),
+			},
+			lagging: []string{"control plane a1b2c"},
+
+			expectConditions: []apiv1beta1.ConditionType{key.CreationStuckCondition},
+			expectMessage:    "Nodes of control plane a1b2c do not have version 8.7.6.",
+		},
+		// The cluster was created, the stuck condition is removed
+		{
+			name: "case 2",
+
+			conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+				unittest.GetCreatedCondition(10),
+				unittest.GetCreatingCondition(40),
+			},
+			stuck: []apiv1beta1.ConditionType{key.CreationStuckCondition},
+		},
+		// The cluster is upgrading for longer than the threshold
+		{
+			name: "case 3",
+
+			conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+				unittest.GetUpdatingCondition(150),
+				unittest.GetCreatedCondition(300),
+				unittest.GetCreatingCondition(310),
+			},
+			lagging: []string{"node pool x1y2z", "node pool z9y8x"},
+
+			expectConditions: []apiv1beta1.ConditionType{key.UpgradeStuckCondition},
+			expectMessage:    "Nodes of node pool x1y2z, node pool z9y8x do not have version 8.7.6.",
+		},
+		// The cluster was upgraded, the stuck condition is removed
+		{
+			name: "case 4",
+
+			conditions: []infrastructurev1alpha3.CommonClusterStatusCondition{
+				unittest.GetUpdatedCondition(10),
+				unittest.GetUpdatingCondition(150),
+				unittest.GetCreatedCondition(300),
+				unittest.GetCreatingCondition(310),
+			},
+			stuck: []apiv1beta1.ConditionType{key.UpgradeStuckCondition},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ctx := context.Background()
+
+			var e recorder.Interface
+			{
+				c := recorder.Config{
+					K8sClient: k8sclienttest.NewEmpty(),
+				}
+				e = recorder.New(c)
+			}
+
+			r := Resource{
+				event:  e,
+				logger: microloggertest.New(),
+
+				creationStuckThreshold: 30 * time.Minute,
+				upgradeStuckThreshold:  2 * time.Hour,
+			}
+
+			cl := &apiv1beta1.Cluster{}
+			for _, c := range tc.stuck {
+				conditions.Set(cl, &apiv1beta1.Condition{Type: c, Status: corev1.ConditionTrue})
+			}
+
+			status := infrastructurev1alpha3.CommonClusterStatus{
+				Conditions: tc.conditions,
+			}
+			r.writeStuckConditions(ctx, cl, status, tc.lagging, "8.7.6")
+
+			if len(cl.GetConditions()) != len(tc.expectConditions) {
+				t.Fatalf("expected %d conditions, got %d", len(tc.expectConditions), len(cl.GetConditions()))
+			}
+			for _, c := range tc.expectConditions {
+				if !conditions.IsTrue(cl, c) {
+					t.Fatalf("expected %#q condition to be true", c)
+				}
+				if !strings.HasSuffix(conditions.GetMessage(cl, c), tc.expectMessage) {
+					t.Fatalf("expected %#q condition message to end with %#q, got %#q", c, tc.expectMessage, conditions.GetMessage(cl, c))
+				}
+			}
+		})
+	}
+}
+
+func TestLaggingNodeGroups(t *testing.T) {
+	versionLabel := "aws-operator.giantswarm.io/version"
+
+	nodes := []corev1.Node{
+		newTestNode("master-0", map[string]string{label.ControlPlane: "a1b2c", versionLabel: "8.7.5"}),
+		newTestNode("worker-0", map[string]string{label.MachineDeployment: "x1y2z", versionLabel: "8.7.6"}),
+		newTestNode("worker-1", map[string]string{label.MachineDeployment: "z9y8x", versionLabel: "8.7.5"}),
+		newTestNode("worker-2", map[string]string{label.MachineDeployment: "z9y8x"}),
+		newTestNode("worker-3", map[string]string{versionLabel: "8.7.5"}),
+	}
+
+	groups := laggingNodeGroups(nodes, "8.7.6", versionLabel)
+
+	expected := []string{"control plane a1b2c", "node pool z9y8x", "node worker-3"}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, groups)
+	}
+}
+
+func newTestNode(name string, labels map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
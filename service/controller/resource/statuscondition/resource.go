@@ -1,6 +1,8 @@
 package statuscondition
 
 import (
+	"time"
+
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
@@ -22,8 +24,14 @@ type Config struct {
 	ReleaseVersion releaseversion.Interface
 	TenantClient   tenantclient.Interface
 
+	// CreationStuckThreshold is the duration after which a cluster still
+	// creating is reported in the CreationStuck condition.
+	CreationStuckThreshold     time.Duration
 	NewCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	Provider                   string
+	// UpgradeStuckThreshold is the duration after which a cluster still
+	// upgrading is reported in the UpgradeStuck condition.
+	UpgradeStuckThreshold time.Duration
 }
 
 type Resource struct {
@@ -33,8 +41,10 @@ type Resource struct {
 	releaseVersion releaseversion.Interface
 	tenantClient   tenantclient.Interface
 
+	creationStuckThreshold     time.Duration
 	newCommonClusterObjectFunc func() infrastructurev1alpha3.CommonClusterObject
 	provider                   string
+	upgradeStuckThreshold      time.Duration
 }
 
 func New(config Config) (*Resource, error) {
@@ -54,12 +64,18 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.TenantClient must not be empty", config)
 	}
 
+	if config.CreationStuckThreshold == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CreationStuckThreshold must not be empty", config)
+	}
 	if config.NewCommonClusterObjectFunc == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
 	}
 	if config.Provider == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
 	}
+	if config.UpgradeStuckThreshold == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.UpgradeStuckThreshold must not be empty", config)
+	}
 
 	r := &Resource{
 		event:          config.Event,
@@ -68,8 +84,10 @@ func New(config Config) (*Resource, error) {
 		releaseVersion: config.ReleaseVersion,
 		tenantClient:   config.TenantClient,
 
+		creationStuckThreshold:     config.CreationStuckThreshold,
 		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 		provider:                   config.Provider,
+		upgradeStuckThreshold:      config.UpgradeStuckThreshold,
 	}
 
 	return r, nil
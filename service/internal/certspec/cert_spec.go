@@ -0,0 +1,166 @@
+package certspec
+
+import (
+	"context"
+	"time"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/microerror"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/hamaster"
+)
+
+type Config struct {
+	BaseDomain basedomain.Interface
+	HAMaster   hamaster.Interface
+
+	KubeConfigVariants []key.KubeConfigVariant
+
+	APIIP         string
+	CATTL         string
+	CertTTL       string
+	ClusterDomain string
+	Provider      string
+}
+
+// CertSpec computes the certificates of tenant clusters shared by all
+// certificate backends.
+type CertSpec struct {
+	baseDomain basedomain.Interface
+	haMaster   hamaster.Interface
+
+	kubeConfigVariants []key.KubeConfigVariant
+
+	apiIP         string
+	caTTL         time.Duration
+	certTTL       string
+	clusterDomain string
+	provider      string
+}
+
+func New(config Config) (*CertSpec, error) {
+	if config.BaseDomain == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.BaseDomain must not be empty", config)
+	}
+	if config.HAMaster == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.HAMaster must not be empty", config)
+	}
+
+	if config.APIIP == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.APIIP must not be empty", config)
+	}
+	if config.CATTL == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CATTL must not be empty", config)
+	}
+	if config.CertTTL == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CertTTL must not be empty", config)
+	}
+	if config.ClusterDomain == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterDomain must not be empty", config)
+	}
+	if config.Provider == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
+	}
+
+	caTTL, err := time.ParseDuration(config.CATTL)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CATTL must be a duration, got %#q", config, config.CATTL)
+	}
+
+	s := &CertSpec{
+		baseDomain: config.BaseDomain,
+		haMaster:   config.HAMaster,
+
+		kubeConfigVariants: config.KubeConfigVariants,
+
+		apiIP:         config.APIIP,
+		caTTL:         caTTL,
+		certTTL:       config.CertTTL,
+		clusterDomain: config.ClusterDomain,
+		provider:      config.Provider,
+	}
+
+	return s, nil
+}
+
+func (s *CertSpec) Specs(ctx context.Context, cr apiv1beta1.Cluster) ([]corev1alpha1.CertConfigSpecCert, error) {
+	// We need to determine how many etcd member certificates we want to
+	// generate. Tenant Clusters with a single master get one etcd certificate.
+	// HA Master setups get one certificate per control plane node.
+	replicas, err := s.haMaster.Replicas(ctx, key.ClusterID(&cr))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	bd, err := s.baseDomain.BaseDomain(ctx, &cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	apiSpec, err := s.newSpecForAPI(ctx, bd, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var specs []corev1alpha1.CertConfigSpecCert
+	{
+		specs = append(specs, apiSpec)
+		specs = append(specs, s.newSpecForAppOperator(ctx, bd, cr))
+		specs = append(specs, s.newSpecForAWSOperator(ctx, bd, cr))
+		specs = append(specs, s.newSpecForCalico(ctx, bd, cr))
+		specs = append(specs, s.newSpecForClusterOperator(ctx, bd, cr))
+		specs = append(specs, s.newSpecForNodeOperator(ctx, bd, cr))
+		specs = append(specs, s.newSpecForPrometheus(ctx, bd, cr))
+		specs = append(specs, s.newSpecForPrometheusEtcdClient(ctx, bd, cr))
+		specs = append(specs, s.newSpecForServiceAccount(ctx, bd, cr))
+		specs = append(specs, s.newSpecForWorker(ctx, bd, cr))
+
+		if replicas > 1 {
+			for i := 1; i <= replicas; i++ {
+				specs = append(specs, s.newSpecForEtcdMember(ctx, bd, cr, i))
+			}
+		} else {
+			specs = append(specs, s.newSpecForEtcd(ctx, bd, cr))
+		}
+
+		if s.provider == label.ProviderKVM {
+			specs = append(specs, s.newSpecForFlanneldEtcdClient(ctx, bd, cr))
+		}
+
+		// Kubeconfig variants defining organizations instead of referencing
+		// an existing certificate get a dedicated identity.
+		for _, v := range s.kubeConfigVariants {
+			if len(v.Organizations) > 0 {
+				specs = append(specs, s.newSpecForKubeConfigVariant(ctx, bd, cr, v))
+			}
+		}
+	}
+
+	// Certificate TTLs may be configured per tenant cluster and component
+	// using annotations on the Cluster CR. Everything else uses the
+	// installation wide default.
+	{
+		var components []string
+		for _, c := range specs {
+			components = append(components, c.ClusterComponent)
+		}
+
+		overrides, err := key.CertTTLOverrides(&cr, components, s.caTTL)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for i := range specs {
+			ttl, ok := overrides[specs[i].ClusterComponent]
+			if ok {
+				specs[i].TTL = ttl
+			}
+		}
+	}
+
+	return specs, nil
+}
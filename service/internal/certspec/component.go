@@ -0,0 +1,213 @@
+package certspec
+
+import (
+	"context"
+	"fmt"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/certs/v4/pkg/certs"
+	"github.com/giantswarm/microerror"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+)
+
+func (s *CertSpec) newSpecForAPI(ctx context.Context, bd string, cr apiv1beta1.Cluster) (corev1alpha1.CertConfigSpecCert, error) {
+	extraAltNames, err := key.APICertAltNames(&cr)
+	if err != nil {
+		return corev1alpha1.CertConfigSpecCert{}, microerror.Mask(err)
+	}
+	extraIPSANs, err := key.APICertIPSANs(&cr)
+	if err != nil {
+		return corev1alpha1.CertConfigSpecCert{}, microerror.Mask(err)
+	}
+
+	defaultAltNames := key.CertDefaultAltNames(s.clusterDomain)
+	desiredAltNames := append(defaultAltNames,
+		fmt.Sprintf("master.%s", key.ClusterID(&cr)),
+		key.InternalAPIEndpoint(&cr, bd),
+	)
+	desiredAltNames = append(desiredAltNames, extraAltNames...)
+
+	desiredIPSANs := []string{s.apiIP, key.LocalhostIP}
+	desiredIPSANs = append(desiredIPSANs, extraIPSANs...)
+
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		AltNames:         desiredAltNames,
+		ClusterComponent: certs.APICert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("api.%s.k8s.%s", key.ClusterID(&cr), bd),
+		IPSANs:           desiredIPSANs,
+		Organizations:    []string{"system:masters"},
+		TTL:              s.certTTL,
+	}, nil
+}
+
+func (s *CertSpec) newSpecForAppOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.AppOperatorAPICert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("app-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
+		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
+		//
+		//     https://github.com/giantswarm/giantswarm/issues/6822
+		//
+		Organizations: []string{"system:masters"},
+		TTL:           s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForAWSOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.AWSOperatorAPICert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("aws-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
+		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
+		//
+		//     https://github.com/giantswarm/giantswarm/issues/6822
+		//
+		Organizations: []string{"system:masters"},
+		TTL:           s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForCalico(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.CalicoEtcdClientCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("calico.%s.k8s.%s", key.ClusterID(&cr), bd),
+		TTL:              s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForClusterOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.ClusterOperatorAPICert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("cluster-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
+		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
+		//
+		//     https://github.com/giantswarm/giantswarm/issues/6822
+		//
+		Organizations: []string{"system:masters"},
+		TTL:           s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForEtcd(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.EtcdCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("etcd.%s.k8s.%s", key.ClusterID(&cr), bd),
+		IPSANs:           []string{"127.0.0.1"},
+		TTL:              s.certTTL,
+	}
+}
+
+// newSpecForEtcdMember returns the spec of the certificate of the etcd member
+// running on the given control plane node of a HA Master setup. Members are
+// numbered starting at 1, e.g. etcd1, etcd2 and etcd3 for three control plane
+// nodes.
+func (s *CertSpec) newSpecForEtcdMember(ctx context.Context, bd string, cr apiv1beta1.Cluster, member int) corev1alpha1.CertConfigSpecCert {
+	name := fmt.Sprintf("etcd%d", member)
+
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: name,
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("etcd.%s.k8s.%s", key.ClusterID(&cr), bd),
+		AltNames: []string{
+			fmt.Sprintf("%s.%s.k8s.%s", name, key.ClusterID(&cr), bd),
+		},
+		IPSANs: []string{"127.0.0.1"},
+		TTL:    s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForFlanneldEtcdClient(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.FlanneldEtcdClientCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("flanneld-etcd-client.%s.k8s.%s", key.ClusterID(&cr), bd),
+		TTL:              s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForKubeConfigVariant(ctx context.Context, bd string, cr apiv1beta1.Cluster, v key.KubeConfigVariant) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: key.KubeConfigVariantCertificate(v),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("%s.kubeconfig.%s.k8s.%s", v.Name, key.ClusterID(&cr), bd),
+		Organizations:    v.Organizations,
+		TTL:              s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForNodeOperator(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.NodeOperatorCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("node-operator.%s.k8s.%s", key.ClusterID(&cr), bd),
+		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
+		//
+		//     https://github.com/giantswarm/giantswarm/issues/6822
+		//
+		Organizations: []string{"system:masters"},
+		TTL:           s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForPrometheus(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.PrometheusCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("prometheus.%s.k8s.%s", key.ClusterID(&cr), bd),
+		// TODO drop system:masters once RBAC rules are in place in tenant clusters.
+		//
+		//     https://github.com/giantswarm/giantswarm/issues/6822
+		//
+		Organizations: []string{"system:masters"},
+		TTL:           s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForPrometheusEtcdClient(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.PrometheusEtcdClientCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("prometheus-etcd-client.%s.k8s.%s", key.ClusterID(&cr), bd),
+		TTL:              s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForServiceAccount(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: certs.ServiceAccountCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("service-actxount.%s.k8s.%s", key.ClusterID(&cr), bd),
+		TTL:              s.certTTL,
+	}
+}
+
+func (s *CertSpec) newSpecForWorker(ctx context.Context, bd string, cr apiv1beta1.Cluster) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		AltNames:         key.CertDefaultAltNames(s.clusterDomain),
+		ClusterComponent: certs.WorkerCert.String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("worker.%s.k8s.%s", key.ClusterID(&cr), bd),
+		TTL:              s.certTTL,
+	}
+}
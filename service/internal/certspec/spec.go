@@ -0,0 +1,15 @@
+package certspec
+
+import (
+	"context"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type Interface interface {
+	// Specs returns the specs of all certificates of the given tenant cluster,
+	// independent of the backend issuing them. It returns an error matched by
+	// hamaster.IsNotFound in case the control plane CR does not exist yet.
+	Specs(ctx context.Context, cr apiv1beta1.Cluster) ([]corev1alpha1.CertConfigSpecCert, error)
+}
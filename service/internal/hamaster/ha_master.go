@@ -42,9 +42,9 @@ func New(config Config) (*HAMaster, error) {
 	return h, nil
 }
 
-func (h *HAMaster) Enabled(ctx context.Context, cluster string) (bool, error) {
+func (h *HAMaster) Replicas(ctx context.Context, cluster string) (int, error) {
 	if h.provider != label.ProviderAWS {
-		return false, nil
+		return 1, nil
 	}
 
 	var list infrastructurev1alpha3.G8sControlPlaneList
@@ -55,16 +55,32 @@ func (h *HAMaster) Enabled(ctx context.Context, cluster string) (bool, error) {
 		client.MatchingLabels{label.Cluster: cluster},
 	)
 	if err != nil {
-		return false, microerror.Mask(err)
+		return 0, microerror.Mask(err)
 	}
 
-	if len(list.Items) == 0 {
-		return false, microerror.Mask(notFoundError)
+	var controlPlanes []infrastructurev1alpha3.G8sControlPlane
+	for _, cp := range list.Items {
+		if key.IsDeleted(&cp) {
+			continue
+		}
+
+		controlPlanes = append(controlPlanes, cp)
 	}
 
-	if key.G8sControlPlaneReplicas(list.Items[0]) == 1 {
-		return false, nil
+	if len(controlPlanes) == 0 {
+		return 0, microerror.Mask(notFoundError)
+	}
+	if len(controlPlanes) > 1 {
+		return 0, microerror.Maskf(tooManyCRsError, "expected 1 G8sControlPlane CR for cluster %#q, got %d", cluster, len(controlPlanes))
+	}
+
+	replicas := key.G8sControlPlaneReplicas(controlPlanes[0])
+	if current := int(controlPlanes[0].Status.Replicas); current > replicas {
+		replicas = current
+	}
+	if replicas < 1 {
+		replicas = 1
 	}
 
-	return true, nil
+	return replicas, nil
 }
@@ -0,0 +1,132 @@
+package hamaster
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_HAMaster_Replicas(t *testing.T) {
+	testCases := []struct {
+		name             string
+		provider         string
+		controlPlanes    []infrastructurev1alpha3.G8sControlPlane
+		expectedReplicas int
+		errorMatcher     func(error) bool
+	}{
+		{
+			name:             "case 0: non AWS providers have a single master",
+			provider:         label.ProviderKVM,
+			expectedReplicas: 1,
+		},
+		{
+			name:     "case 1: single master",
+			provider: label.ProviderAWS,
+			controlPlanes: []infrastructurev1alpha3.G8sControlPlane{
+				newControlPlane("a2wax", 1, 1),
+			},
+			expectedReplicas: 1,
+		},
+		{
+			name:     "case 2: five masters",
+			provider: label.ProviderAWS,
+			controlPlanes: []infrastructurev1alpha3.G8sControlPlane{
+				newControlPlane("a2wax", 5, 5),
+			},
+			expectedReplicas: 5,
+		},
+		{
+			name:     "case 3: scaling up uses the desired replicas",
+			provider: label.ProviderAWS,
+			controlPlanes: []infrastructurev1alpha3.G8sControlPlane{
+				newControlPlane("a2wax", 5, 3),
+			},
+			expectedReplicas: 5,
+		},
+		{
+			name:     "case 4: scaling down keeps the current replicas",
+			provider: label.ProviderAWS,
+			controlPlanes: []infrastructurev1alpha3.G8sControlPlane{
+				newControlPlane("a2wax", 3, 5),
+			},
+			expectedReplicas: 5,
+		},
+		{
+			name:         "case 5: control plane not found",
+			provider:     label.ProviderAWS,
+			errorMatcher: IsNotFound,
+		},
+		{
+			name:     "case 6: multiple control planes",
+			provider: label.ProviderAWS,
+			controlPlanes: []infrastructurev1alpha3.G8sControlPlane{
+				newControlPlane("a2wax", 3, 3),
+				newControlPlane("b3xby", 3, 3),
+			},
+			errorMatcher: IsTooManyCRsError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := unittest.FakeK8sClient()
+
+			for i := range tc.controlPlanes {
+				err := k8sClient.CtrlClient().Create(ctx, &tc.controlPlanes[i])
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var h *HAMaster
+			{
+				c := Config{
+					K8sClient: k8sClient,
+
+					Provider: tc.provider,
+				}
+
+				var err error
+				h, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			replicas, err := h.Replicas(ctx, "8y5ck")
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if replicas != tc.expectedReplicas {
+				t.Fatalf("replicas == %d, want %d", replicas, tc.expectedReplicas)
+			}
+		})
+	}
+}
+
+func newControlPlane(name string, desired, current int) infrastructurev1alpha3.G8sControlPlane {
+	cr := unittest.DefaultControlPlane()
+	cr.Name = name
+	cr.Namespace = "default"
+	cr.Labels = map[string]string{
+		label.Cluster: "8y5ck",
+	}
+	cr.Spec.Replicas = desired
+	cr.Status.Replicas = int32(current)
+
+	return cr
+}
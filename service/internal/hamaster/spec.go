@@ -3,5 +3,10 @@ package hamaster
 import "context"
 
 type Interface interface {
-	Enabled(ctx context.Context, cluster string) (bool, error)
+	// Replicas returns the number of control plane nodes of the given tenant
+	// cluster, which is the number of etcd members certificates have to be
+	// issued for. During scaling it is the higher of the desired and the
+	// current number of control plane nodes, so that certificates of members
+	// still running are not removed before their machines are gone.
+	Replicas(ctx context.Context, cluster string) (int, error)
 }
@@ -0,0 +1,32 @@
+package meshid
+
+import "github.com/giantswarm/microerror"
+
+var conflictError = &microerror.Error{
+	Kind: "conflictError",
+	Desc: "The mesh ID of the cluster is recorded for another cluster.",
+}
+
+// IsConflict asserts conflictError.
+func IsConflict(err error) bool {
+	return microerror.Cause(err) == conflictError
+}
+
+var exhaustedError = &microerror.Error{
+	Kind: "exhaustedError",
+	Desc: "All mesh IDs of the configured range are allocated.",
+}
+
+// IsExhausted asserts exhaustedError.
+func IsExhausted(err error) bool {
+	return microerror.Cause(err) == exhaustedError
+}
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
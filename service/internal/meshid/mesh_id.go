@@ -0,0 +1,289 @@
+package meshid
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
+	"github.com/giantswarm/cluster-operator/v5/pkg/project"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap recording all mesh IDs
+	// allocated within the installation.
+	ConfigMapName = "cilium-cluster-mesh-ids"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+
+	Max int
+	Min int
+	// Namespace is the namespace of the ConfigMap recording all mesh IDs
+	// allocated within the installation.
+	Namespace string
+}
+
+// MeshID allocates Cilium cluster mesh IDs which are unique within the
+// installation. All allocations are recorded in a single ConfigMap mapping
+// IDs to Cluster CRs. Every allocation updates the ConfigMap based on the
+// resourceVersion it read, so that concurrent reconciliations of different
+// clusters cannot allocate the same ID. The losing reconciliation gets a
+// conflict error and allocates again on the next reconciliation.
+//
+// The allocated ID is rendered into the cilium values based on the annotation
+// of the Cluster CR. Once the annotation is set the ID is never changed by
+// the allocator, not even in case the configured range changes.
+type MeshID struct {
+	k8sClient k8sclient.Interface
+
+	max       int
+	min       int
+	namespace string
+}
+
+func New(c Config) (*MeshID, error) {
+	if c.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", c)
+	}
+
+	if c.Min < 1 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Min must be greater than 0", c)
+	}
+	if c.Max < c.Min {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Max must not be smaller than %T.Min", c, c)
+	}
+	if c.Namespace == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Namespace must not be empty", c)
+	}
+
+	m := &MeshID{
+		k8sClient: c.K8sClient,
+
+		max:       c.Max,
+		min:       c.Min,
+		namespace: c.Namespace,
+	}
+
+	return m, nil
+}
+
+func (m *MeshID) ID(ctx context.Context, obj interface{}) (int, error) {
+	cr, err := m.cluster(ctx, obj)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+
+	cm, err := m.configMap(ctx)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+
+	owner := ownerName(cr)
+
+	// An ID which is already rendered into the cilium values is kept. It is
+	// recorded in the ConfigMap in case it is not yet, e.g. because the
+	// annotation was set before the ConfigMap existed.
+	if id, ok := parse(cr); ok {
+		o, recorded := cm.Data[strconv.Itoa(id)]
+		if recorded && o == owner {
+			return id, nil
+		}
+		if recorded {
+			// The ID cannot be taken away from either cluster without
+			// breaking its datapath, so this needs manual intervention.
+			return 0, microerror.Maskf(conflictError, "mesh ID %d of cluster %s is recorded for cluster %s", id, owner, o)
+		}
+
+		err = m.record(ctx, cm, id, owner)
+		if err != nil {
+			return 0, microerror.Mask(err)
+		}
+
+		return id, nil
+	}
+
+	// The ID may have been recorded without the annotation being set
+	// afterwards, e.g. because the update of the Cluster CR failed.
+	id, ok := recordedID(cm, owner)
+	if !ok {
+		for i := m.min; i <= m.max; i++ {
+			if _, ok := cm.Data[strconv.Itoa(i)]; !ok {
+				id = i
+				break
+			}
+		}
+
+		if id == 0 {
+			return 0, microerror.Maskf(exhaustedError, "no free mesh ID in range [%d, %d]", m.min, m.max)
+		}
+
+		err = m.record(ctx, cm, id, owner)
+		if err != nil {
+			return 0, microerror.Mask(err)
+		}
+	}
+
+	{
+		if cr.Annotations == nil {
+			cr.Annotations = map[string]string{}
+		}
+		cr.Annotations[annotation.CiliumClusterMeshID] = strconv.Itoa(id)
+
+		err = m.k8sClient.CtrlClient().Update(ctx, cr)
+		if err != nil {
+			return 0, microerror.Mask(err)
+		}
+	}
+
+	return id, nil
+}
+
+func (m *MeshID) Release(ctx context.Context, obj interface{}) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = m.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: ConfigMapName, Namespace: m.namespace}, cm)
+	if apierrors.IsNotFound(err) {
+		// Nothing was ever allocated.
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	owner := fmt.Sprintf("%s/%s", accessor.GetNamespace(), accessor.GetName())
+
+	var released bool
+	for id, o := range cm.Data {
+		if o == owner {
+			delete(cm.Data, id)
+			released = true
+		}
+	}
+
+	if !released {
+		return nil
+	}
+
+	err = m.k8sClient.CtrlClient().Update(ctx, cm)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// cluster fetches the latest version of the Cluster CR, since the allocation
+// must be based on the current annotations.
+func (m *MeshID) cluster(ctx context.Context, obj interface{}) (*apiv1beta1.Cluster, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	cr := &apiv1beta1.Cluster{}
+	err = m.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: accessor.GetName(), Namespace: accessor.GetNamespace()}, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return cr, nil
+}
+
+// configMap fetches the ConfigMap recording the allocations and creates it in
+// case it does not exist yet. Concurrent creations fail with an already exists
+// error, which is retried on the next reconciliation.
+func (m *MeshID) configMap(ctx context.Context) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	err := m.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: ConfigMapName, Namespace: m.namespace}, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ConfigMapName,
+				Namespace: m.namespace,
+				Labels: map[string]string{
+					label.ManagedBy: project.Name(),
+				},
+			},
+		}
+
+		err = m.k8sClient.CtrlClient().Create(ctx, cm)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+
+	return cm, nil
+}
+
+// record persists the allocation of the given ID. The update fails with a
+// conflict in case the ConfigMap changed since it was read.
+func (m *MeshID) record(ctx context.Context, cm *corev1.ConfigMap, id int, owner string) error {
+	cm.Data[strconv.Itoa(id)] = owner
+
+	err := m.k8sClient.CtrlClient().Update(ctx, cm)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// recordedID returns the lowest ID recorded for the given owner.
+func recordedID(cm *corev1.ConfigMap, owner string) (int, bool) {
+	var ids []int
+	for k, o := range cm.Data {
+		id, err := strconv.Atoi(k)
+		if err == nil && o == owner {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return 0, false
+	}
+
+	sort.Ints(ids)
+
+	return ids[0], true
+}
+
+// parse returns the mesh ID rendered for the given Cluster CR.
+func parse(cr *apiv1beta1.Cluster) (int, bool) {
+	v, ok := cr.Annotations[annotation.CiliumClusterMeshID]
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
+
+func ownerName(cr *apiv1beta1.Cluster) string {
+	return fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)
+}
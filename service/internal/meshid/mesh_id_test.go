@@ -0,0 +1,271 @@
+package meshid
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
+)
+
+func Test_MeshID_ID(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cluster      *apiv1beta1.Cluster
+		allocations  map[string]string
+		expectedID   int
+		errorMatcher func(error) bool
+	}{
+		{
+			name:       "case 0: first cluster gets the lowest ID",
+			cluster:    newCluster("8y5ck", ""),
+			expectedID: 1,
+		},
+		{
+			name:    "case 1: rendered ID is kept and recorded",
+			cluster: newCluster("8y5ck", "3"),
+			allocations: map[string]string{
+				"1": "default/al9qy",
+			},
+			expectedID: 3,
+		},
+		{
+			name:    "case 2: lowest free ID is allocated",
+			cluster: newCluster("8y5ck", ""),
+			allocations: map[string]string{
+				"1": "default/al9qy",
+				"3": "default/b3x7q",
+			},
+			expectedID: 2,
+		},
+		{
+			name:    "case 3: recorded ID is annotated",
+			cluster: newCluster("8y5ck", ""),
+			allocations: map[string]string{
+				"1": "default/al9qy",
+				"3": "default/8y5ck",
+			},
+			expectedID: 3,
+		},
+		{
+			name:       "case 4: rendered ID out of range is kept",
+			cluster:    newCluster("8y5ck", "42"),
+			expectedID: 42,
+		},
+		{
+			name:    "case 5: rendered ID recorded for another cluster is not reassigned",
+			cluster: newCluster("8y5ck", "1"),
+			allocations: map[string]string{
+				"1": "default/al9qy",
+			},
+			expectedID:   0,
+			errorMatcher: IsConflict,
+		},
+		{
+			name:    "case 6: range exhausted",
+			cluster: newCluster("8y5ck", ""),
+			allocations: map[string]string{
+				"1": "default/al9qy",
+				"2": "default/b3x7q",
+				"3": "default/c4z8r",
+			},
+			errorMatcher: IsExhausted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := unittest.FakeK8sClient()
+
+			objects := []client.Object{tc.cluster}
+			if tc.allocations != nil {
+				objects = append(objects, newConfigMap(tc.allocations))
+			}
+			for _, o := range objects {
+				err := k8sClient.CtrlClient().Create(ctx, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var m *MeshID
+			{
+				c := Config{
+					K8sClient: k8sClient,
+
+					Max:       3,
+					Min:       1,
+					Namespace: "giantswarm",
+				}
+
+				var err error
+				m, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			id, err := m.ID(ctx, tc.cluster)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if id != tc.expectedID {
+				t.Fatalf("id == %d, want %d", id, tc.expectedID)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			var cr apiv1beta1.Cluster
+			err = k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: tc.cluster.Name, Namespace: tc.cluster.Namespace}, &cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cr.Annotations[annotation.CiliumClusterMeshID] != strconv.Itoa(tc.expectedID) {
+				t.Fatalf("annotation == %#q, want %d", cr.Annotations[annotation.CiliumClusterMeshID], tc.expectedID)
+			}
+
+			var cm corev1.ConfigMap
+			err = k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: ConfigMapName, Namespace: "giantswarm"}, &cm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cm.Data[strconv.Itoa(tc.expectedID)] != "default/8y5ck" {
+				t.Fatalf("allocation == %#q, want %#q", cm.Data[strconv.Itoa(tc.expectedID)], "default/8y5ck")
+			}
+
+			// Allocating again must return the same ID.
+			id, err = m.ID(ctx, tc.cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tc.expectedID {
+				t.Fatalf("id == %d, want %d", id, tc.expectedID)
+			}
+		})
+	}
+}
+
+func Test_MeshID_ID_Conflict(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := unittest.FakeK8sClient()
+
+	m, err := New(Config{K8sClient: k8sClient, Max: 3, Min: 1, Namespace: "giantswarm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = k8sClient.CtrlClient().Create(ctx, newConfigMap(map[string]string{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two reconciliations read the same version of the allocation
+	// ConfigMap. Only the first one can record its allocation.
+	first, err := m.configMap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := m.configMap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.record(ctx, first, 1, "default/8y5ck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = m.record(ctx, second, 1, "default/al9qy")
+	if err == nil {
+		t.Fatalf("error == nil, want non-nil")
+	}
+}
+
+func Test_MeshID_Release(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := unittest.FakeK8sClient()
+
+	cl := newCluster("8y5ck", "1")
+	cm := newConfigMap(map[string]string{
+		"1": "default/8y5ck",
+		"2": "default/al9qy",
+	})
+	for _, o := range []client.Object{cl, cm} {
+		err := k8sClient.CtrlClient().Create(ctx, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := New(Config{K8sClient: k8sClient, Max: 3, Min: 1, Namespace: "giantswarm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.Release(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: ConfigMapName, Namespace: "giantswarm"}, cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cm.Data["1"]; ok {
+		t.Fatalf("expected mesh ID %d to be released", 1)
+	}
+	if _, ok := cm.Data["2"]; !ok {
+		t.Fatalf("expected mesh ID %d to be kept", 2)
+	}
+
+	// Releasing the ID of a cluster without allocation must not fail.
+	err = m.Release(ctx, newCluster("b3x7q", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newCluster(name string, id string) *apiv1beta1.Cluster {
+	cl := &apiv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	if id != "" {
+		cl.Annotations = map[string]string{
+			annotation.CiliumClusterMeshID: id,
+		}
+	}
+
+	return cl
+}
+
+func newConfigMap(allocations map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: "giantswarm",
+		},
+		Data: allocations,
+	}
+}
@@ -0,0 +1,18 @@
+package meshid
+
+import (
+	"context"
+)
+
+type Interface interface {
+	// ID returns the Cilium cluster mesh ID of the tenant cluster represented
+	// by the given Cluster CR. In case the cluster has no ID yet, the lowest
+	// free ID of the configured range is allocated, recorded in the
+	// allocation ConfigMap and persisted in the annotations of the Cluster
+	// CR. An ID once persisted in the annotations is never changed.
+	ID(ctx context.Context, obj interface{}) (int, error)
+	// Release frees the Cilium cluster mesh ID of the tenant cluster
+	// represented by the given Cluster CR in the allocation ConfigMap, so
+	// that it can be allocated to other clusters again.
+	Release(ctx context.Context, obj interface{}) error
+}
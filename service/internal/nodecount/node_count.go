@@ -119,6 +119,35 @@ func (nc *NodeCount) WorkerCount(ctx context.Context, obj interface{}) (map[stri
 	return workerCount, nil
 }
 
+func (nc *NodeCount) WorkerVersionCount(ctx context.Context, obj interface{}, versionLabel string, version string) (map[string]NodeVersion, error) {
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	nodes, err := nc.cachedNodes(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	versionCount := make(map[string]NodeVersion)
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[label.WorkerNodeRole]; ok {
+			id := node.Labels[label.MachineDeployment]
+
+			val := versionCount[id]
+			if node.Labels[versionLabel] == version {
+				val.Upgraded++
+			} else {
+				val.Outdated++
+			}
+			versionCount[id] = val
+		}
+	}
+
+	return versionCount, nil
+}
+
 func (nc *NodeCount) cachedNodes(ctx context.Context, cr metav1.Object) (corev1.NodeList, error) {
 	var err error
 	var ok bool
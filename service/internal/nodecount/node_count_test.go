@@ -2,6 +2,8 @@ package nodecount
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"strconv"
 	"testing"
 
@@ -9,6 +11,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/giantswarm/cluster-operator/v5/pkg/label"
 	tcunittest "github.com/giantswarm/cluster-operator/v5/service/internal/tenantclient/unittest"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/unittest"
 )
@@ -172,3 +175,60 @@ func TestIsMaster(t *testing.T) {
 	}
 
 }
+
+func Test_NodeCount_WorkerVersionCount(t *testing.T) {
+	versionLabel := "aws-operator.giantswarm.io/version"
+
+	fakeK8sClient := unittest.FakeK8sClient()
+
+	nc, err := New(Config{
+		K8sClient:    fakeK8sClient,
+		TenantClient: tcunittest.FakeTenantClient(fakeK8sClient),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workers := []struct {
+		machineDeployment string
+		version           string
+	}{
+		{machineDeployment: "a1b2c", version: "8.7.6"},
+		{machineDeployment: "a1b2c", version: "8.7.5"},
+		{machineDeployment: "x1y2z", version: "8.7.6"},
+		{machineDeployment: "x1y2z", version: "8.7.6"},
+	}
+
+	ctx := context.Background()
+	for i, w := range workers {
+		node := unittest.NewWorkerNode()
+		node.Name = fmt.Sprintf("worker-%d", i)
+		node.Labels[label.MachineDeployment] = w.machineDeployment
+		node.Labels[versionLabel] = w.version
+
+		_, err = fakeK8sClient.K8sClient().CoreV1().Nodes().Create(ctx, &node, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	master := unittest.NewMasterNode()
+	_, err = fakeK8sClient.K8sClient().CoreV1().Nodes().Create(ctx, &master, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := unittest.DefaultCluster()
+	versionCount, err := nc.WorkerVersionCount(ctx, &cl, versionLabel, "8.7.6")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]NodeVersion{
+		"a1b2c": {Upgraded: 1, Outdated: 1},
+		"x1y2z": {Upgraded: 2},
+	}
+	if !reflect.DeepEqual(versionCount, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, versionCount)
+	}
+}
@@ -13,6 +13,11 @@ type Interface interface {
 	// ID. The map value is a structure holding node information for the
 	// corresponding node pools.
 	WorkerCount(ctx context.Context, obj interface{}) (map[string]Node, error)
+	// WorkerVersionCount is a map of key value pairs where the key is the
+	// machine deployment ID. The map value is a structure holding the number of
+	// worker nodes of the corresponding node pool which carry the given version
+	// in the given label and the number of nodes which do not.
+	WorkerVersionCount(ctx context.Context, obj interface{}, versionLabel string, version string) (map[string]NodeVersion, error)
 }
 
 // Node holds the node information for a control plane or a machine deployment
@@ -20,3 +25,10 @@ type Node struct {
 	Nodes int32
 	Ready int32
 }
+
+// NodeVersion holds the version information of the nodes of a machine
+// deployment.
+type NodeVersion struct {
+	Upgraded int32
+	Outdated int32
+}
@@ -0,0 +1,21 @@
+package proxy
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidProxyError = &microerror.Error{
+	Kind: "invalidProxyError",
+}
+
+// IsInvalidProxy asserts invalidProxyError.
+func IsInvalidProxy(err error) bool {
+	return microerror.Cause(err) == invalidProxyError
+}
@@ -0,0 +1,221 @@
+package proxy
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v5/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/podcidr"
+)
+
+const (
+	// Keys of the proxy Secret users create in the namespace of the Cluster CR.
+	httpProxyKey  = "httpProxy"
+	httpsProxyKey = "httpsProxy"
+	noProxyKey    = "noProxy"
+)
+
+type Config struct {
+	BaseDomain basedomain.Interface
+	K8sClient  k8sclient.Interface
+	PodCIDR    podcidr.Interface
+
+	ClusterDomain  string
+	ClusterIPRange string
+}
+
+type Proxy struct {
+	baseDomain basedomain.Interface
+	k8sClient  k8sclient.Interface
+	podCIDR    podcidr.Interface
+
+	clusterDomain  string
+	clusterIPRange string
+}
+
+func New(c Config) (*Proxy, error) {
+	if c.BaseDomain == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.BaseDomain must not be empty", c)
+	}
+	if c.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", c)
+	}
+	if c.PodCIDR == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.PodCIDR must not be empty", c)
+	}
+
+	if c.ClusterDomain == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterDomain must not be empty", c)
+	}
+	if c.ClusterIPRange == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterIPRange must not be empty", c)
+	}
+
+	p := &Proxy{
+		baseDomain: c.BaseDomain,
+		k8sClient:  c.K8sClient,
+		podCIDR:    c.PodCIDR,
+
+		clusterDomain:  c.ClusterDomain,
+		clusterIPRange: c.ClusterIPRange,
+	}
+
+	return p, nil
+}
+
+func (p *Proxy) Settings(ctx context.Context, obj interface{}) (Settings, error) {
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return Settings{}, microerror.Mask(err)
+	}
+
+	var s Settings
+	var noProxy []string
+	{
+		s.HTTP = cr.GetAnnotations()[annotation.HTTPProxy]
+		s.HTTPS = cr.GetAnnotations()[annotation.HTTPSProxy]
+		noProxy = append(noProxy, splitList(cr.GetAnnotations()[annotation.NoProxy])...)
+	}
+
+	{
+		secret, err := p.k8sClient.K8sClient().CoreV1().Secrets(cr.GetNamespace()).Get(ctx, key.ProxyConfigSecretName(cr), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return Settings{}, microerror.Mask(err)
+		} else {
+			if v := string(secret.Data[httpProxyKey]); v != "" {
+				s.HTTP = v
+			}
+			if v := string(secret.Data[httpsProxyKey]); v != "" {
+				s.HTTPS = v
+			}
+			noProxy = append(noProxy, splitList(string(secret.Data[noProxyKey]))...)
+		}
+	}
+
+	if !s.Enabled() {
+		return Settings{}, nil
+	}
+
+	for _, u := range []string{s.HTTP, s.HTTPS} {
+		err = validateURL(u)
+		if err != nil {
+			return Settings{}, microerror.Mask(err)
+		}
+	}
+
+	podCIDR, err := p.podCIDR.PodCIDR(ctx, obj)
+	if err != nil {
+		return Settings{}, microerror.Mask(err)
+	}
+	bd, err := p.baseDomain.BaseDomain(ctx, obj)
+	if err != nil {
+		return Settings{}, microerror.Mask(err)
+	}
+
+	// The cluster internal networks and the API endpoint must always be
+	// reachable without going through the proxy, regardless of what users
+	// configure.
+	s.NoProxy = uniqueList(append([]string{
+		p.clusterIPRange,
+		podCIDR,
+		p.clusterDomain,
+		key.APIEndpoint(cr, bd),
+	}, noProxy...))
+
+	return s, nil
+}
+
+// Enabled returns true in case any proxy is configured.
+func (s Settings) Enabled() bool {
+	return s.HTTP != "" || s.HTTPS != ""
+}
+
+// Redacted returns a copy of the settings with any user credentials removed
+// from the proxy URLs, so the settings can be stored in ConfigMaps.
+func (s Settings) Redacted() Settings {
+	return Settings{
+		HTTP:    redactURL(s.HTTP),
+		HTTPS:   redactURL(s.HTTPS),
+		NoProxy: s.NoProxy,
+	}
+}
+
+// Values returns the settings in the format of the proxy key in Helm values.
+func (s Settings) Values() map[string]interface{} {
+	return map[string]interface{}{
+		"http":    s.HTTP,
+		"https":   s.HTTPS,
+		"noProxy": strings.Join(s.NoProxy, ","),
+	}
+}
+
+func redactURL(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+
+	return u.String()
+}
+
+func splitList(s string) []string {
+	var l []string
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			l = append(l, v)
+		}
+	}
+
+	return l
+}
+
+func uniqueList(l []string) []string {
+	seen := map[string]bool{}
+
+	var u []string
+	for _, v := range l {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		u = append(u, v)
+	}
+
+	return u
+}
+
+func validateURL(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return microerror.Maskf(invalidProxyError, "proxy URL %#q is invalid: %s", redactURL(s), err.Error())
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return microerror.Maskf(invalidProxyError, "proxy URL %#q must use the http or https scheme", redactURL(s))
+	}
+	if u.Host == "" {
+		return microerror.Maskf(invalidProxyError, "proxy URL %#q must have a host", redactURL(s))
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package proxy
+
+import (
+	"context"
+)
+
+type Interface interface {
+	// Settings provides the HTTP proxy settings of the given tenant cluster.
+	// The settings are read from the cluster's proxy Secret and the proxy
+	// annotations of the Cluster CR, where the Secret takes precedence. The
+	// returned settings are empty in case no proxy is configured.
+	Settings(ctx context.Context, obj interface{}) (Settings, error)
+}
+
+// Settings holds the HTTP proxy configuration of a tenant cluster.
+type Settings struct {
+	HTTP    string
+	HTTPS   string
+	NoProxy []string
+}
@@ -9,4 +9,6 @@ import (
 type Interface interface {
 	// Emit is used to create Kubernetes events.
 	Emit(ctx context.Context, obj pkgruntime.Object, reason, message string)
+	// Warn is used to create Kubernetes warning events.
+	Warn(ctx context.Context, obj pkgruntime.Object, reason, message string)
 }
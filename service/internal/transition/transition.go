@@ -0,0 +1,108 @@
+package transition
+
+import (
+	"time"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/cluster-operator/v5/pkg/annotation"
+)
+
+const (
+	Create = "create"
+	Delete = "delete"
+	Update = "update"
+)
+
+type Transition struct {
+	Name string
+	// Annotation is the name of the annotation on the Cluster CR recording
+	// that the completed transition was observed.
+	Annotation string
+	Start      time.Time
+	End        time.Time
+}
+
+// Marker returns the value of the annotation recording that the transition
+// was observed.
+func (t Transition) Marker() string {
+	return t.Start.UTC().Format(time.RFC3339)
+}
+
+// NewDurations returns the histogram observing the duration of completed
+// cluster transitions. It is observed by the clustertransition resource and
+// exposed by the cluster transition collector.
+func NewDurations() *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "cluster_operator",
+			Subsystem: "cluster",
+			Name:      "transition_duration_seconds",
+			Help:      "Duration of completed cluster transitions. Every transition is observed once.",
+			// Buckets from one minute up to roughly eight and a half hours.
+			Buckets: prometheus.ExponentialBuckets(60, 2, 10),
+		},
+		[]string{
+			"provider",
+			"release_version",
+			"transition",
+		},
+	)
+}
+
+// Completed returns the completed creation and the latest completed update
+// transition of the given status. Only the AWS infrastructure CR carries the
+// Creating, Created, Updating and Updated status conditions, so transitions
+// are not known for other providers.
+func Completed(status infrastructurev1alpha3.CommonClusterStatus) []Transition {
+	var transitions []Transition
+
+	if status.HasCreatingCondition() && status.HasCreatedCondition() {
+		transitions = append(transitions, Transition{
+			Name:       Create,
+			Annotation: annotation.ObservedCreateTransition,
+			Start:      status.GetCreatingCondition().LastTransitionTime.Time,
+			End:        status.GetCreatedCondition().LastTransitionTime.Time,
+		})
+	}
+
+	if status.HasUpdatingCondition() && status.HasUpdatedCondition() {
+		start := status.GetUpdatingCondition().LastTransitionTime.Time
+		end := status.GetUpdatedCondition().LastTransitionTime.Time
+
+		// The latest Updated condition only completes the latest Updating
+		// condition in case it happened afterwards.
+		if end.After(start) {
+			transitions = append(transitions, Transition{
+				Name:       Update,
+				Annotation: annotation.ObservedUpdateTransition,
+				Start:      start,
+				End:        end,
+			})
+		}
+	}
+
+	return transitions
+}
+
+// Open returns the ongoing creation or update transition of the given status.
+func Open(status infrastructurev1alpha3.CommonClusterStatus) []Transition {
+	var transitions []Transition
+
+	if status.HasCreatingCondition() && !status.HasCreatedCondition() {
+		transitions = append(transitions, Transition{
+			Name:  Create,
+			Start: status.GetCreatingCondition().LastTransitionTime.Time,
+		})
+	}
+
+	if status.LatestCondition() == infrastructurev1alpha3.ClusterStatusConditionUpdating {
+		transitions = append(transitions, Transition{
+			Name:  Update,
+			Start: status.GetUpdatingCondition().LastTransitionTime.Time,
+		})
+	}
+
+	return transitions
+}
@@ -88,13 +88,13 @@ func GetCreatedCondition(minutesAgo time.Duration) infrastructurev1alpha3.Common
 func GetDeletedCondition(minutesAgo time.Duration) infrastructurev1alpha3.CommonClusterStatusCondition {
 	return infrastructurev1alpha3.CommonClusterStatusCondition{
 		LastTransitionTime: metav1.NewTime(time.Now().Add(-minutesAgo * time.Minute)),
-		Condition:          infrastructurev1alpha3.ClusterStatusConditionDeleting,
+		Condition:          infrastructurev1alpha3.ClusterStatusConditionDeleted,
 	}
 }
 func GetDeletingCondition(minutesAgo time.Duration) infrastructurev1alpha3.CommonClusterStatusCondition {
 	return infrastructurev1alpha3.CommonClusterStatusCondition{
 		LastTransitionTime: metav1.NewTime(time.Now().Add(-minutesAgo * time.Minute)),
-		Condition:          infrastructurev1alpha3.ClusterStatusConditionDeleted,
+		Condition:          infrastructurev1alpha3.ClusterStatusConditionDeleting,
 	}
 }
 func GetUpdatingCondition(minutesAgo time.Duration) infrastructurev1alpha3.CommonClusterStatusCondition {
@@ -1,16 +1,21 @@
 package unittest
 
 import (
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	providerv1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/k8sclient/v7/pkg/k8scrdclient"
 	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck // v0.6.4 has a deprecation on pkg/client/fake that was removed in later versions
 )
@@ -26,14 +31,34 @@ func FakeK8sClient() k8sclient.Interface {
 	var k8sClient k8sclient.Interface
 	{
 		scheme := runtime.NewScheme()
+		err = corev1.AddToScheme(scheme)
+		if err != nil {
+			panic(err)
+		}
+		err = applicationv1alpha1.AddToScheme(scheme)
+		if err != nil {
+			panic(err)
+		}
+		err = corev1alpha1.AddToScheme(scheme)
+		if err != nil {
+			panic(err)
+		}
 		err = infrastructurev1alpha3.AddToScheme(scheme)
 		if err != nil {
 			panic(err)
 		}
+		err = providerv1alpha1.AddToScheme(scheme)
+		if err != nil {
+			panic(err)
+		}
 		err = releasev1alpha1.AddToScheme(scheme)
 		if err != nil {
 			panic(err)
 		}
+		err = capiv1beta1.AddToScheme(scheme)
+		if err != nil {
+			panic(err)
+		}
 
 		k8sClient = &fakeK8sClient{
 			ctrlClient: fake.NewClientBuilder().WithScheme(scheme).Build(),
@@ -36,6 +36,7 @@ import (
 	"github.com/giantswarm/cluster-operator/v5/service/internal/recorder"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/releaseversion"
 	"github.com/giantswarm/cluster-operator/v5/service/internal/tenantclient"
+	"github.com/giantswarm/cluster-operator/v5/service/internal/transition"
 )
 
 const (
@@ -85,7 +86,24 @@ func New(config Config) (*Service, error) {
 	calicoCIDR := config.Viper.GetString(config.Flag.Guest.Cluster.Calico.CIDR)
 	clusterIPRange := config.Viper.GetString(config.Flag.Guest.Cluster.Kubernetes.API.ClusterIPRange)
 	provider := config.Viper.GetString(config.Flag.Service.Provider.Kind)
+	meshIDMax := config.Viper.GetInt(config.Flag.Guest.Cluster.Cilium.MeshIDMax)
+	meshIDMin := config.Viper.GetInt(config.Flag.Guest.Cluster.Cilium.MeshIDMin)
 	registryDomain := config.Viper.GetString(config.Flag.Service.Image.Registry.Domain)
+	registryMirrors := config.Viper.GetStringSlice(config.Flag.Service.Image.Registry.Mirrors)
+
+	certificateExpiryThreshold, err := time.ParseDuration(config.Viper.GetString(config.Flag.Service.Collector.CertificateExpiryThreshold))
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%#q must be a duration", config.Flag.Service.Collector.CertificateExpiryThreshold)
+	}
+
+	creationStuckThreshold, err := time.ParseDuration(config.Viper.GetString(config.Flag.Guest.Cluster.Transition.CreationStuckThreshold))
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%#q must be a duration", config.Flag.Guest.Cluster.Transition.CreationStuckThreshold)
+	}
+	upgradeStuckThreshold, err := time.ParseDuration(config.Viper.GetString(config.Flag.Guest.Cluster.Transition.UpgradeStuckThreshold))
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%#q must be a duration", config.Flag.Guest.Cluster.Transition.UpgradeStuckThreshold)
+	}
 
 	var restConfig *rest.Config
 	{
@@ -260,32 +278,52 @@ func New(config Config) (*Service, error) {
 		eventRecorder = recorder.New(c)
 	}
 
+	// The durations of completed cluster transitions are observed by the
+	// cluster controller and exposed by the operator collector.
+	transitionDurations := transition.NewDurations()
+
 	var controllers []operatorkitController
 	{
 		{
 			c := controller.ClusterConfig{
-				BaseDomain:     bd,
-				CertsSearcher:  certsSearcher,
-				Event:          eventRecorder,
-				FileSystem:     afero.NewOsFs(),
-				K8sClient:      k8sClient,
-				Logger:         config.Logger,
-				PodCIDR:        pc,
-				Tenant:         tenantCluster,
-				ReleaseVersion: rv,
+				BaseDomain:          bd,
+				CertsSearcher:       certsSearcher,
+				Event:               eventRecorder,
+				FileSystem:          afero.NewOsFs(),
+				K8sClient:           k8sClient,
+				Logger:              config.Logger,
+				PodCIDR:             pc,
+				Tenant:              tenantCluster,
+				ReleaseVersion:      rv,
+				TransitionDurations: transitionDurations,
 
 				APIIP:                      apiIP,
+				CATTL:                      config.Viper.GetString(config.Flag.Guest.Cluster.Vault.Certificate.CATTL),
+				CertBackend:                config.Viper.GetString(config.Flag.Guest.Cluster.Certificate.Backend),
 				CertTTL:                    config.Viper.GetString(config.Flag.Guest.Cluster.Vault.Certificate.TTL),
 				ClusterIPRange:             clusterIPRange,
 				DNSIP:                      dnsIP,
 				ClusterDomain:              config.Viper.GetString(config.Flag.Guest.Cluster.Kubernetes.ClusterDomain),
+				CreationStuckThreshold:     creationStuckThreshold,
 				KiamWatchDogEnabled:        config.Viper.GetBool(config.Flag.Service.Release.App.Config.KiamWatchDogEnabled),
 				Installation:               config.Viper.GetString(config.Flag.Service.Installation.Name),
+				InstallationAPIEndpoint:    config.Viper.GetString(config.Flag.Service.Installation.APIEndpoint),
+				InstallationRegion:         config.Viper.GetString(config.Flag.Service.Installation.Region),
+				KubeConfigMirrorNamespace:  config.Viper.GetString(config.Flag.Service.KubeConfig.Secret.Namespace),
+				KubeConfigMirrorSelector:   config.Viper.GetString(config.Flag.Service.KubeConfig.Secret.NamespaceSelector),
+				KubeConfigOIDCClientID:     config.Viper.GetString(config.Flag.Service.KubeConfig.OIDC.ClientID),
+				KubeConfigOIDCIssuerURL:    config.Viper.GetString(config.Flag.Service.KubeConfig.OIDC.IssuerURL),
+				MeshIDMax:                  meshIDMax,
+				MeshIDMin:                  meshIDMin,
+				MeshIDNamespace:            config.Viper.GetString(config.Flag.Guest.Cluster.Cilium.MeshIDNamespace),
 				NewCommonClusterObjectFunc: newCommonClusterObjectFunc(provider),
 				Provider:                   provider,
 				RawAppDefaultConfig:        config.Viper.GetString(config.Flag.Service.Release.App.Config.Default),
 				RawAppOverrideConfig:       config.Viper.GetString(config.Flag.Service.Release.App.Config.Override),
+				RawKubeConfigVariants:      config.Viper.GetString(config.Flag.Service.KubeConfig.Variants),
 				RegistryDomain:             registryDomain,
+				RegistryMirrors:            registryMirrors,
+				UpgradeStuckThreshold:      upgradeStuckThreshold,
 			}
 
 			clusterController, err := controller.NewCluster(c)
@@ -344,11 +382,14 @@ func New(config Config) (*Service, error) {
 	{
 		c := collector.SetConfig{
 			CertSearcher: certsSearcher,
+			Event:        eventRecorder,
 			K8sClient:    k8sClient,
 			Logger:       config.Logger,
 
+			CertificateExpiryThreshold: certificateExpiryThreshold,
 			NewCommonClusterObjectFunc: newCommonClusterObjectFunc(provider),
 			Provider:                   provider,
+			TransitionDurations:        transitionDurations,
 		}
 
 		operatorCollector, err = collector.NewSet(c)